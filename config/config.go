@@ -11,13 +11,18 @@ import (
 
 // Config структура для конфигурации приложения
 type Config struct {
-	Local Local    `yaml:"local"`
-	DB    DBConfig `yaml:"db"`
+	Local     Local           `yaml:"local"`
+	DB        DBConfig        `yaml:"db"`
+	Exchange  ExchangeConfig  `yaml:"exchange"`
+	Scheduler SchedulerConfig `yaml:"scheduler"`
+	Rates     RatesConfig     `yaml:"rates"`
+	Health    HealthConfig    `yaml:"health"`
 }
 
 // Local структура для конфигурации локальных параметров
 type Local struct {
-	Port int `yaml:"port"` // Порт для сервера
+	Port       int  `yaml:"port"`       // Порт для сервера
+	Reflection bool `yaml:"reflection"` // Включить gRPC server reflection, например для grpcurl
 }
 
 // DBConfig структура для конфигурации базы данных
@@ -32,6 +37,120 @@ type DBConfig struct {
 	TimeOut  time.Duration `yaml:"timeout"`  // Таймаут подключения
 }
 
+// HealthConfig структура для конфигурации проверок состояния приложения
+type HealthConfig struct {
+	CheckTimeout  time.Duration `yaml:"check_timeout"`  // Таймаут одной проверки компонента
+	FetchMaxAge   time.Duration `yaml:"fetch_max_age"`  // Максимальное время с последнего успешного запроса к бирже, 0 — не проверять
+	WatchInterval time.Duration `yaml:"watch_interval"` // Период фоновых проверок для потока Watch
+}
+
+// ExchangeConfig структура для конфигурации источников курсов
+type ExchangeConfig struct {
+	Provider  string           `yaml:"provider"`  // Имя используемого провайдера курсов
+	Providers []ProviderConfig `yaml:"providers"` // Настройки доступных провайдеров
+	Composite CompositeConfig  `yaml:"composite"` // Настройки составного провайдера
+	Failover  []string         `yaml:"failover"`  // Провайдеры в порядке переключения (provider: failover)
+}
+
+// CompositeConfig структура для конфигурации составного провайдера (provider: composite)
+type CompositeConfig struct {
+	Providers    []string           `yaml:"providers"`     // Имена провайдеров, участвующих в расчете
+	MaxDeviation float64            `yaml:"max_deviation"` // Допустимое отклонение от медианы, доля (0.01 = 1%)
+	MinSources   int                `yaml:"min_sources"`   // Минимальное число источников для расчета курса
+	Weights      map[string]float64 `yaml:"weights"`       // Веса провайдеров, по умолчанию 1
+}
+
+// ProviderConfig структура для конфигурации провайдера курсов
+type ProviderConfig struct {
+	Name    string        `yaml:"name"`    // Имя провайдера, например garantex
+	URL     string        `yaml:"url"`     // Базовый URL API биржи
+	Timeout time.Duration `yaml:"timeout"` // Таймаут HTTP запроса к бирже
+	Markets []string      `yaml:"markets"` // Рынки, поддерживаемые провайдером
+	Retry   RetryConfig   `yaml:"retry"`   // Повторы запросов при временных ошибках
+	Breaker BreakerConfig `yaml:"breaker"` // Автоматический выключатель провайдера
+	HTTP    HTTPConfig    `yaml:"http"`    // Настройки HTTP клиента провайдера
+	Capture CaptureConfig `yaml:"capture"` // Сохранение сырых ответов биржи
+	Replay  ReplayConfig  `yaml:"replay"`  // Воспроизведение сохраненных ответов, для провайдера replay
+}
+
+// CaptureConfig структура для конфигурации сохранения ответов биржи
+type CaptureConfig struct {
+	Enabled  bool          `yaml:"enabled"`   // Сохранять тело, заголовки и задержку каждого ответа
+	Dir      string        `yaml:"dir"`       // Каталог для сохраненных ответов
+	MaxFiles int           `yaml:"max_files"` // Максимальное число ответов на рынок, 0 — 1000
+	MaxAge   time.Duration `yaml:"max_age"`   // Ответы старше удаляются, 0 — без ограничения по возрасту
+}
+
+// ReplayConfig структура для конфигурации воспроизведения сохраненных ответов
+type ReplayConfig struct {
+	Dir  string `yaml:"dir"`  // Каталог с сохраненными ответами
+	Loop bool   `yaml:"loop"` // Начинать сначала, когда ответы закончились
+}
+
+// HTTPConfig структура для конфигурации HTTP клиента провайдера
+type HTTPConfig struct {
+	UserAgent             string        `yaml:"user_agent"`              // Заголовок User-Agent запросов к бирже
+	Proxy                 string        `yaml:"proxy"`                   // URL прокси, пусто — из переменных окружения HTTP_PROXY/HTTPS_PROXY
+	DialTimeout           time.Duration `yaml:"dial_timeout"`            // Таймаут установки TCP соединения
+	TLSHandshakeTimeout   time.Duration `yaml:"tls_handshake_timeout"`   // Таймаут TLS рукопожатия
+	ResponseHeaderTimeout time.Duration `yaml:"response_header_timeout"` // Таймаут ожидания заголовков ответа
+	IdleConnTimeout       time.Duration `yaml:"idle_conn_timeout"`       // Время жизни простаивающего соединения
+	MaxIdleConns          int           `yaml:"max_idle_conns"`          // Максимум простаивающих соединений
+	MaxIdleConnsPerHost   int           `yaml:"max_idle_conns_per_host"` // Максимум простаивающих соединений с одним хостом
+	MaxConnsPerHost       int           `yaml:"max_conns_per_host"`      // Максимум соединений с одним хостом, 0 — без ограничений
+	MaxBodySize           int64         `yaml:"max_body_size"`           // Максимальный размер тела ответа в байтах, 0 — 1 МиБ
+	TLS                   TLSConfig     `yaml:"tls"`                     // Настройки TLS
+}
+
+// TLSConfig структура для конфигурации TLS соединения с биржей
+type TLSConfig struct {
+	CAFile             string `yaml:"ca_file"`              // Дополнительный корневой сертификат, например корпоративного прокси
+	ServerName         string `yaml:"server_name"`          // Имя сервера для проверки сертификата
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify"` // Отключает проверку сертификата, только для локальных стендов
+}
+
+// RetryConfig структура для конфигурации повторов запросов к бирже
+type RetryConfig struct {
+	Attempts       int           `yaml:"attempts"`        // Максимальное число попыток, 0 или 1 — без повторов
+	InitialBackoff time.Duration `yaml:"initial_backoff"` // Начальная задержка между попытками
+	MaxBackoff     time.Duration `yaml:"max_backoff"`     // Максимальная задержка между попытками
+}
+
+// BreakerConfig структура для конфигурации автоматического выключателя
+type BreakerConfig struct {
+	FailureThreshold int           `yaml:"failure_threshold"` // Число ошибок подряд для размыкания, 0 — выключатель отключен
+	OpenTimeout      time.Duration `yaml:"open_timeout"`      // Время до пробного запроса после размыкания
+}
+
+// SchedulerConfig структура для конфигурации фонового опроса провайдеров
+type SchedulerConfig struct {
+	Enabled  bool          `yaml:"enabled"`  // Включен ли фоновый опрос
+	Interval time.Duration `yaml:"interval"` // Интервал опроса каждого провайдера
+	Jitter   time.Duration `yaml:"jitter"`   // Максимальное случайное смещение интервала
+}
+
+// RatesConfig структура для конфигурации выдачи курсов
+type RatesConfig struct {
+	Source        string           `yaml:"source"`         // Источник курса для GetRates: live или storage
+	MaxAge        time.Duration    `yaml:"max_age"`        // Максимальный возраст сохраненного курса
+	Markets       []string         `yaml:"markets"`        // Включенные рынки, первый используется по умолчанию
+	SnapshotDepth int              `yaml:"snapshot_depth"` // Количество сохраняемых уровней стакана с каждой стороны
+	CacheTTL      time.Duration    `yaml:"cache_ttl"`      // Время жизни курса в кэше GetRates, 0 — без кэша
+	Validation    ValidationConfig `yaml:"validation"`     // Проверки стакана перед принятием курса
+	ReadyMaxAge   time.Duration    `yaml:"ready_max_age"`  // Максимальный возраст последнего сохраненного курса для готовности, 0 — не проверять
+}
+
+// ValidationConfig структура для конфигурации проверок курса.
+// Нулевое значение правила отключает его.
+type ValidationConfig struct {
+	Action       string        `yaml:"action"`         // Действие при непройденной проверке: reject или flag
+	CrossedBook  bool          `yaml:"crossed_book"`   // Проверять, что bid меньше ask
+	MaxSpread    float64       `yaml:"max_spread"`     // Максимальный спред относительно середины, например 0.05 — 5%
+	MaxMove      float64       `yaml:"max_move"`       // Максимальное изменение середины относительно последнего сохраненного курса
+	MaxMoveAge   time.Duration `yaml:"max_move_age"`   // Возраст, после которого сохраненный курс не участвует в max_move, 0 — rates.max_age
+	MinTopVolume float64       `yaml:"min_top_volume"` // Минимальный объем лучшей заявки с каждой стороны
+}
+
 // MustLoad загружает конфигурацию из файла и возвращает структуру Config
 // Функция завершает выполнение программы с ошибкой, если конфигурацию не удается загрузить
 func MustLoad() *Config {
@@ -0,0 +1,38 @@
+package migrate
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(upAddRatesMarket, downAddRatesMarket)
+}
+
+func upAddRatesMarket(tx *sql.Tx) error {
+	// Добавление рынка к курсам; существующие записи относятся к usdtrub
+	_, err := tx.Exec(`
+        ALTER TABLE rates ADD COLUMN IF NOT EXISTS market TEXT NOT NULL DEFAULT 'usdtrub'; -- Рынок курса
+        CREATE INDEX IF NOT EXISTS rates_market_timestamp_idx ON rates (market, timestamp, id);
+    `)
+	if err != nil {
+		return fmt.Errorf("could not add market to rates table: %v", err)
+	}
+
+	return nil
+}
+
+func downAddRatesMarket(tx *sql.Tx) error {
+	// Удаление рынка из курсов
+	_, err := tx.Exec(`
+        DROP INDEX IF EXISTS rates_market_timestamp_idx;
+        ALTER TABLE rates DROP COLUMN IF EXISTS market;
+    `)
+	if err != nil {
+		return fmt.Errorf("could not drop market from rates table: %v", err)
+	}
+
+	return nil
+}
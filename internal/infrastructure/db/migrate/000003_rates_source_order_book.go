@@ -0,0 +1,55 @@
+package migrate
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(upAddRatesSourceAndOrderBook, downAddRatesSourceAndOrderBook)
+}
+
+func upAddRatesSourceAndOrderBook(tx *sql.Tx) error {
+	// Добавление источника курса; рынок добавлен в предыдущей миграции
+	_, err := tx.Exec(`
+        ALTER TABLE rates ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT '';           -- Провайдер, вернувший курс
+    `)
+	if err != nil {
+		return fmt.Errorf("could not add source to rates table: %v", err)
+	}
+
+	// Создание таблицы снимков стакана
+	_, err = tx.Exec(`
+        CREATE TABLE IF NOT EXISTS order_book_levels (
+            rate_id INTEGER NOT NULL REFERENCES rates (id) ON DELETE CASCADE, -- Курс, для которого сделан снимок
+            source TEXT NOT NULL,                                             -- Провайдер, вернувший стакан
+            market TEXT NOT NULL,                                             -- Рынок стакана
+            side TEXT NOT NULL,                                               -- Сторона стакана: ask или bid
+            level INTEGER NOT NULL,                                           -- Номер уровня, 0 — лучшая цена
+            price NUMERIC NOT NULL,                                           -- Цена уровня
+            volume NUMERIC,                                                   -- Объем уровня
+            amount NUMERIC,                                                   -- Сумма уровня
+            PRIMARY KEY (rate_id, side, level)
+        );
+    `)
+	if err != nil {
+		return fmt.Errorf("could not create order_book_levels table: %v", err)
+	}
+
+	return nil
+}
+
+func downAddRatesSourceAndOrderBook(tx *sql.Tx) error {
+	// Удаление таблицы снимков стакана и источника курса
+	_, err := tx.Exec(`
+        DROP TABLE IF EXISTS order_book_levels;
+        ALTER TABLE rates DROP COLUMN IF EXISTS source;
+    `)
+	if err != nil {
+		return fmt.Errorf("could not drop order book snapshot: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package migrate
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(upAddRatesSources, downAddRatesSources)
+}
+
+func upAddRatesSources(tx *sql.Tx) error {
+	// Добавление списка бирж, участвовавших в расчете курса
+	_, err := tx.Exec(`
+        ALTER TABLE rates ADD COLUMN IF NOT EXISTS sources TEXT[] NOT NULL DEFAULT '{}'; -- Биржи, участвовавшие в расчете курса
+    `)
+	if err != nil {
+		return fmt.Errorf("could not add sources to rates table: %v", err)
+	}
+
+	return nil
+}
+
+func downAddRatesSources(tx *sql.Tx) error {
+	// Удаление списка бирж из курсов
+	_, err := tx.Exec(`
+        ALTER TABLE rates DROP COLUMN IF EXISTS sources;
+    `)
+	if err != nil {
+		return fmt.Errorf("could not drop sources from rates table: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package migrate
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(upAddRatesFlags, downAddRatesFlags)
+}
+
+func upAddRatesFlags(tx *sql.Tx) error {
+	// Добавление пометок о непройденных проверках курса
+	_, err := tx.Exec(`
+        ALTER TABLE rates ADD COLUMN IF NOT EXISTS flags TEXT[] NOT NULL DEFAULT '{}'; -- Проверки, которые курс не прошел
+    `)
+	if err != nil {
+		return fmt.Errorf("could not add flags to rates table: %v", err)
+	}
+
+	return nil
+}
+
+func downAddRatesFlags(tx *sql.Tx) error {
+	// Удаление пометок из курсов
+	_, err := tx.Exec(`
+        ALTER TABLE rates DROP COLUMN IF EXISTS flags;
+    `)
+	if err != nil {
+		return fmt.Errorf("could not drop flags from rates table: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package migrate
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/pressly/goose"
+)
+
+func init() {
+	goose.AddMigration(upAddRatesExchangeTime, downAddRatesExchangeTime)
+}
+
+func upAddRatesExchangeTime(tx *sql.Tx) error {
+	// Добавление времени стакана по часам биржи
+	_, err := tx.Exec(`
+        ALTER TABLE rates ADD COLUMN IF NOT EXISTS exchange_time TIMESTAMP WITH TIME ZONE; -- Время стакана по часам биржи
+    `)
+	if err != nil {
+		return fmt.Errorf("could not add exchange_time to rates table: %v", err)
+	}
+
+	return nil
+}
+
+func downAddRatesExchangeTime(tx *sql.Tx) error {
+	// Удаление времени биржи из курсов
+	_, err := tx.Exec(`
+        ALTER TABLE rates DROP COLUMN IF EXISTS exchange_time;
+    `)
+	if err != nil {
+		return fmt.Errorf("could not drop exchange_time from rates table: %v", err)
+	}
+
+	return nil
+}
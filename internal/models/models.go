@@ -1,14 +1,151 @@
 package models
 
-import "time"
+import (
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrRateNotFound возвращается, когда в хранилище нет подходящего курса
+	ErrRateNotFound = errors.New("rate not found")
+	// ErrInvalidArgument возвращается при некорректных параметрах запроса
+	ErrInvalidArgument = errors.New("invalid argument")
+	// ErrRateRejected возвращается, когда полученный курс не прошел проверку
+	ErrRateRejected = errors.New("rate rejected by validation")
+	// ErrComponentNotFound возвращается при запросе состояния незарегистрированного компонента
+	ErrComponentNotFound = errors.New("component not found")
+	// ErrProviderUnavailable возвращается, когда биржа или все провайдеры не вернули пригодный стакан
+	ErrProviderUnavailable = errors.New("rate provider unavailable")
+	// ErrOrderBookUnavailable возвращается, когда для рынка нет провайдера с реальным стаканом
+	ErrOrderBookUnavailable = errors.New("order book unavailable")
+)
 
 type Rate struct {
-	ID        int64     `json:"id" db:"id"`               // Уникальный ID записи курса
-	Ask       float64   `json:"ask" db:"ask"`             // Лучшая цена продажи (ask)
-	Bid       float64   `json:"bid" db:"bid"`             // Лучшая цена покупки (bid)
-	Timestamp time.Time `json:"timestamp" db:"timestamp"` // Временная метка получения курса
+	ID        int64           `json:"id" db:"id"`               // Уникальный ID записи курса
+	Market    string          `json:"market" db:"market"`       // Рынок курса, например usdtrub
+	Source    string          `json:"source" db:"source"`       // Провайдер, вернувший курс
+	Sources   []string        `json:"sources" db:"-"`           // Биржи, участвовавшие в расчете курса
+	Flags     []string        `json:"flags,omitempty" db:"-"`   // Проверки, которые курс не прошел, если он принят с пометкой
+	Ask       decimal.Decimal `json:"ask" db:"ask"`             // Лучшая цена продажи (ask)
+	Bid       decimal.Decimal `json:"bid" db:"bid"`             // Лучшая цена покупки (bid)
+	Timestamp time.Time       `json:"timestamp" db:"timestamp"` // Временная метка получения курса
+	// Время стакана по часам биржи, нулевое, если биржа его не сообщает
+	ExchangeTime time.Time     `json:"exchange_time,omitempty" db:"-"`
+	Age          time.Duration `json:"age" db:"-"`      // Возраст курса на момент ответа
+	Stale        bool          `json:"is_stale" db:"-"` // Курс старше допустимого возраста
+
+	OrderBook *OrderBook `json:"order_book,omitempty" db:"-"` // Снимок стакана, по которому рассчитан курс
+}
+
+// RateCursor позиция в истории курсов для постраничной выдачи
+type RateCursor struct {
+	Timestamp time.Time // Временная метка последнего выданного курса
+	ID        int64     // ID последнего выданного курса
+}
+
+// RateHistoryFilter параметры выборки истории курсов
+type RateHistoryFilter struct {
+	Market string      // Рынок курсов
+	From   time.Time   // Начало периода (включительно)
+	To     time.Time   // Конец периода (не включительно)
+	After  *RateCursor // Выдавать курсы строго после этой позиции
+	Limit  int         // Максимальное количество курсов
+}
+
+// RateHistoryPage страница истории курсов
+type RateHistoryPage struct {
+	Rates         []Rate // Курсы в порядке возрастания времени
+	NextPageToken string // Токен следующей страницы, пустой на последней странице
+}
+
+// Market рынок, доступный в сервисе
+type Market struct {
+	Name      string   `json:"name"`      // Имя рынка, например usdtrub
+	Providers []string `json:"providers"` // Провайдеры, поддерживающие рынок
+}
+
+// OHLC цены открытия, максимума, минимума и закрытия за период
+type OHLC struct {
+	Open  decimal.Decimal `json:"open"`  // Первая цена периода
+	High  decimal.Decimal `json:"high"`  // Максимальная цена периода
+	Low   decimal.Decimal `json:"low"`   // Минимальная цена периода
+	Close decimal.Decimal `json:"close"` // Последняя цена периода
 }
 
+// Candle свеча курса за интервал
+type Candle struct {
+	Start time.Time `json:"start"` // Начало интервала
+	Ask   OHLC      `json:"ask"`   // Свеча по цене ask
+	Bid   OHLC      `json:"bid"`   // Свеча по цене bid
+	Mid   OHLC      `json:"mid"`   // Свеча по средней цене (ask+bid)/2
+	Count int64     `json:"count"` // Количество курсов в интервале, 0 для пустого интервала
+}
+
+// Направления сделки для котировки
+const (
+	QuoteSideBuy  = "buy"  // Покупка базовой валюты по заявкам на продажу
+	QuoteSideSell = "sell" // Продажа базовой валюты по заявкам на покупку
+)
+
+// Quote котировка с учетом глубины стакана для заданного объема
+type Quote struct {
+	Market          string          `json:"market"`           // Рынок котировки
+	Source          string          `json:"source"`           // Провайдер, вернувший стакан
+	Side            string          `json:"side"`             // Направление сделки: buy или sell
+	Amount          decimal.Decimal `json:"amount"`           // Запрошенный объем в базовой валюте
+	Filled          decimal.Decimal `json:"filled"`           // Объем, который можно исполнить по стакану
+	Cost            decimal.Decimal `json:"cost"`             // Стоимость исполненного объема в валюте котировки
+	AveragePrice    decimal.Decimal `json:"average_price"`    // Средневзвешенная цена исполнения
+	TopPrice        decimal.Decimal `json:"top_price"`        // Лучшая цена стакана
+	SlippageBps     decimal.Decimal `json:"slippage_bps"`     // Проскальзывание относительно лучшей цены в б.п.
+	LevelsUsed      int             `json:"levels_used"`      // Количество задействованных уровней стакана
+	EnoughLiquidity bool            `json:"enough_liquidity"` // Хватило ли объема стакана на весь запрос
+	Timestamp       time.Time       `json:"timestamp"`        // Время расчета котировки
+}
+
+// Стороны стакана
+const (
+	SideAsk = "ask" // Заявки на продажу
+	SideBid = "bid" // Заявки на покупку
+)
+
+// OrderBook биржевой стакан, полученный от провайдера курсов
+type OrderBook struct {
+	Source  string           `json:"source"`            // Имя провайдера, вернувшего стакан
+	Market  string           `json:"market"`            // Рынок, например usdtrub
+	Sources []string         `json:"sources,omitempty"` // Биржи, участвовавшие в расчете составного стакана
+	Asks    []OrderBookLevel `json:"asks"`              // Заявки на продажу, от лучшей цены к худшей
+	Bids    []OrderBookLevel `json:"bids"`              // Заявки на покупку, от лучшей цены к худшей
+	// Время стакана по часам биржи, нулевое, если биржа его не сообщает
+	Timestamp time.Time `json:"timestamp,omitempty"`
+}
+
+// OrderBookLevel уровень стакана в том виде, в котором его вернула биржа
+type OrderBookLevel struct {
+	Price  string `json:"price"`  // Цена
+	Volume string `json:"volume"` // Объем в базовой валюте
+	Amount string `json:"amount"` // Сумма в валюте котировки
+}
+
+// Статусы здоровья приложения и его компонентов
+const (
+	StatusInitializing = "Initializing"
+	StatusHealthy      = "Healthy"
+	StatusDegraded     = "Degraded"
+	StatusUnhealthy    = "Unhealthy"
+)
+
 type HealthStatus struct {
-	Status string `json:"status"`
+	Status     string            `json:"status"`
+	Components []ComponentStatus `json:"components,omitempty"`
+}
+
+// ComponentStatus состояние отдельного компонента приложения
+type ComponentStatus struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Critical bool   `json:"critical"` // Отказ компонента делает приложение неработоспособным
 }
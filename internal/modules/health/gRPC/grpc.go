@@ -2,12 +2,17 @@ package grpchealth
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"getUSDT/internal/models"
 	"getUSDT/proto/health/proto"
+	usdtproto "getUSDT/proto/usdt/proto"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // HealthServer — структура для сервера health-сервиса
@@ -19,6 +24,8 @@ type HealthServer struct {
 
 type HealthService interface {
 	CheckHealthStatus(ctx context.Context) (*models.HealthStatus, error)
+	CheckComponent(ctx context.Context, name string) (*models.ComponentStatus, error)
+	Subscribe() (<-chan *models.HealthStatus, func())
 }
 
 // NewHealthServer создаёт новый HealthServer
@@ -34,29 +41,194 @@ func Register(gRPC *grpc.Server, chek HealthService, tr trace.Tracer) {
 	proto.RegisterHealthServer(gRPC, NewHealthServer(chek, tr))
 }
 
-// CheckHealth проверяет состояние сервиса
+// CheckHealth проверяет состояние сервиса.
+// Пустое имя и имя gRPC сервиса курсов возвращают общий статус приложения,
+// остальные имена — статус зарегистрированного компонента, например database или exchange:garantex.
 func (s *HealthServer) Check(ctx context.Context, req *proto.HealthCheckRequest) (*proto.HealthCheckResponse, error) {
 	// Начинаем новый спан для трассировки
 	ctx, span := s.tr.Start(ctx, "CheckHealth")
 	defer span.End()
 
+	span.SetAttributes(attribute.String("health.service", req.GetService()))
+
+	return s.resolve(ctx, req.GetService())
+}
+
+// Watch отправляет текущий статус сервиса сразу после подписки, а затем каждое его изменение.
+// Имя сервиса разрешается так же, как в Check. Изменения берутся из состояний, которые публикует
+// фоновый опрос HealthService, поэтому потоки не запускают проверки компонентов сами.
+// Поток завершается при отключении клиента или остановке HealthService.
+func (s *HealthServer) Watch(req *proto.HealthCheckRequest, stream proto.Health_WatchServer) error {
+	ctx, span := s.tr.Start(stream.Context(), "WatchHealth")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("health.service", req.GetService()))
+
+	// Подписываемся до получения текущего статуса, чтобы не пропустить изменения
+	updates, unsubscribe := s.healthService.Subscribe()
+	defer unsubscribe()
+
+	// Последнее опубликованное состояние уже лежит в канале; проверки выполняются,
+	// только если фоновый опрос еще ничего не опубликовал
+	var (
+		resp *proto.HealthCheckResponse
+		err  error
+	)
+	select {
+	case snapshot, ok := <-updates:
+		if !ok {
+			return status.Error(codes.Unavailable, "health stream closed")
+		}
+		resp, err = fromSnapshot(snapshot, req.GetService())
+	default:
+		resp, err = s.resolve(ctx, req.GetService())
+	}
+	if err != nil {
+		span.RecordError(err)
+		return err
+	}
+	if err := stream.Send(resp); err != nil {
+		return err
+	}
+	last := resp
+
+	sent := 1
+	for {
+		select {
+		case <-ctx.Done():
+			span.SetAttributes(attribute.Int("stream.sent", sent))
+			return nil
+		case snapshot, ok := <-updates:
+			// Канал закрывается при остановке сервиса
+			if !ok {
+				span.SetAttributes(attribute.Int("stream.sent", sent))
+				return status.Error(codes.Unavailable, "health stream closed")
+			}
+			resp, err := fromSnapshot(snapshot, req.GetService())
+			if err != nil {
+				span.RecordError(err)
+				return err
+			}
+			// Изменение другого компонента не влияет на запрошенный сервис
+			if sameStatus(last, resp) {
+				continue
+			}
+			if err := stream.Send(resp); err != nil {
+				span.RecordError(err)
+				return err
+			}
+			last = resp
+			sent++
+		}
+	}
+}
+
+// resolve возвращает статус сервиса по имени из запроса Check или Watch
+func (s *HealthServer) resolve(ctx context.Context, service string) (*proto.HealthCheckResponse, error) {
+	switch service {
+	case "", usdtproto.RatesService_ServiceDesc.ServiceName:
+		return s.checkOverall(ctx)
+	default:
+		return s.checkComponent(ctx, service)
+	}
+}
+
+// fromSnapshot возвращает статус сервиса из опубликованного состояния приложения
+func fromSnapshot(snapshot *models.HealthStatus, service string) (*proto.HealthCheckResponse, error) {
+	switch service {
+	case "", usdtproto.RatesService_ServiceDesc.ServiceName:
+		return toOverallResponse(snapshot)
+	default:
+		for _, c := range snapshot.Components {
+			if c.Name == service {
+				return toComponentResponse(c), nil
+			}
+		}
+		return nil, status.Errorf(codes.NotFound, "unknown service: %s", service)
+	}
+}
+
+// sameStatus сравнивает статус сервиса и статусы его компонентов без учета текста ошибок
+func sameStatus(a, b *proto.HealthCheckResponse) bool {
+	if a.GetStatus() != b.GetStatus() || len(a.GetComponents()) != len(b.GetComponents()) {
+		return false
+	}
+	for i, c := range a.GetComponents() {
+		other := b.GetComponents()[i]
+		if c.GetName() != other.GetName() || c.GetStatus() != other.GetStatus() {
+			return false
+		}
+	}
+	return true
+}
+
+// checkOverall возвращает общий статус приложения
+func (s *HealthServer) checkOverall(ctx context.Context) (*proto.HealthCheckResponse, error) {
 	// Проверка состояния здоровья через сервис
 	status, err := s.healthService.CheckHealthStatus(ctx)
 	if err != nil {
 		return nil, err
 	}
+	return toOverallResponse(status)
+}
+
+// toOverallResponse преобразует общий статус приложения в ответ gRPC
+func toOverallResponse(status *models.HealthStatus) (*proto.HealthCheckResponse, error) {
 
 	// Логика для возвращения статуса через gRPC
 	var servingStatus proto.HealthCheckResponse_ServingStatus
-	if status.Status == "Healthy" {
+	// При деградации отдельных компонентов сервис продолжает обслуживать запросы,
+	// а пока приложение запускается — еще не готов их обслуживать
+	if status.Status == models.StatusHealthy || status.Status == models.StatusDegraded {
 		servingStatus = proto.HealthCheckResponse_SERVING
-	} else if status.Status == "Unhealthy" {
+	} else if status.Status == models.StatusUnhealthy || status.Status == models.StatusInitializing {
 		servingStatus = proto.HealthCheckResponse_NOT_SERVING
 	} else {
 		return nil, fmt.Errorf("unknown health status: %s", status.Status)
 	}
 
+	components := make([]*proto.ComponentStatus, 0, len(status.Components))
+	for _, c := range status.Components {
+		components = append(components, toProtoComponent(c))
+	}
+
 	return &proto.HealthCheckResponse{
-		Status: servingStatus,
+		Status:     servingStatus,
+		Components: components,
 	}, nil
 }
+
+// checkComponent возвращает статус отдельного компонента
+func (s *HealthServer) checkComponent(ctx context.Context, name string) (*proto.HealthCheckResponse, error) {
+	component, err := s.healthService.CheckComponent(ctx, name)
+	if errors.Is(err, models.ErrComponentNotFound) {
+		return nil, status.Errorf(codes.NotFound, "unknown service: %s", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toComponentResponse(*component), nil
+}
+
+// toComponentResponse преобразует состояние компонента в ответ gRPC
+func toComponentResponse(c models.ComponentStatus) *proto.HealthCheckResponse {
+	servingStatus := proto.HealthCheckResponse_SERVING
+	if c.Status != models.StatusHealthy {
+		servingStatus = proto.HealthCheckResponse_NOT_SERVING
+	}
+
+	return &proto.HealthCheckResponse{
+		Status:     servingStatus,
+		Components: []*proto.ComponentStatus{toProtoComponent(c)},
+	}
+}
+
+// toProtoComponent преобразует состояние компонента в gRPC сообщение
+func toProtoComponent(c models.ComponentStatus) *proto.ComponentStatus {
+	return &proto.ComponentStatus{
+		Name:     c.Name,
+		Status:   c.Status,
+		Error:    c.Error,
+		Critical: c.Critical,
+	}
+}
@@ -0,0 +1,83 @@
+package grpchealth
+
+import (
+	"context"
+	"fmt"
+	"getUSDT/internal/models"
+	"getUSDT/proto/health/proto"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// stubHealthService возвращает заранее заданные состояния компонентов
+type stubHealthService struct {
+	overall    string
+	components map[string]string
+}
+
+func (s stubHealthService) CheckHealthStatus(context.Context) (*models.HealthStatus, error) {
+	return &models.HealthStatus{Status: s.overall}, nil
+}
+
+func (s stubHealthService) CheckComponent(_ context.Context, name string) (*models.ComponentStatus, error) {
+	st, ok := s.components[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", models.ErrComponentNotFound, name)
+	}
+	return &models.ComponentStatus{Name: name, Status: st}, nil
+}
+
+func (s stubHealthService) Subscribe() (<-chan *models.HealthStatus, func()) {
+	return make(chan *models.HealthStatus), func() {}
+}
+
+func TestCheck_ResolvesService(t *testing.T) {
+	server := NewHealthServer(stubHealthService{
+		overall: models.StatusDegraded,
+		components: map[string]string{
+			"database":          models.StatusHealthy,
+			"exchange:garantex": models.StatusUnhealthy,
+		},
+	}, noop.NewTracerProvider().Tracer("test"))
+
+	tests := []struct {
+		service string
+		status  proto.HealthCheckResponse_ServingStatus
+	}{
+		{service: "", status: proto.HealthCheckResponse_SERVING},
+		{service: "usdt.RatesService", status: proto.HealthCheckResponse_SERVING},
+		{service: "database", status: proto.HealthCheckResponse_SERVING},
+		{service: "exchange:garantex", status: proto.HealthCheckResponse_NOT_SERVING},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.service, func(t *testing.T) {
+			resp, err := server.Check(context.Background(), &proto.HealthCheckRequest{Service: tt.service})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, resp.GetStatus())
+		})
+	}
+}
+
+func TestCheck_Initializing(t *testing.T) {
+	server := NewHealthServer(stubHealthService{overall: models.StatusInitializing}, noop.NewTracerProvider().Tracer("test"))
+
+	// Пока приложение запускается, Check отвечает так же, как Watch
+	resp, err := server.Check(context.Background(), &proto.HealthCheckRequest{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, proto.HealthCheckResponse_NOT_SERVING, resp.GetStatus())
+}
+
+func TestCheck_UnknownService(t *testing.T) {
+	server := NewHealthServer(stubHealthService{overall: models.StatusHealthy}, noop.NewTracerProvider().Tracer("test"))
+
+	_, err := server.Check(context.Background(), &proto.HealthCheckRequest{Service: "exchange:binance"})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
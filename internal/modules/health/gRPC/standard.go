@@ -0,0 +1,102 @@
+package grpchealth
+
+import (
+	"context"
+	"getUSDT/internal/models"
+	usdtproto "getUSDT/proto/usdt/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// StandardServer реализует стандартный протокол grpc.health.v1 для grpc_health_probe,
+// gRPC проб Kubernetes и grpcurl. Статусы синхронизируются с состояниями, которые публикует HealthService.
+type StandardServer struct {
+	*health.Server
+	done chan struct{} // Закрывается после остановки HealthService
+}
+
+// NewStandardServer создаёт StandardServer и запускает синхронизацию статусов с HealthService.
+// Синхронизация завершается вместе с HealthService, после чего все сервисы переходят в NOT_SERVING.
+func NewStandardServer(healthService HealthService) *StandardServer {
+	s := &StandardServer{
+		Server: health.NewServer(),
+		done:   make(chan struct{}),
+	}
+	// Пока статус не получен, сервис не готов обслуживать запросы
+	s.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	s.SetServingStatus(usdtproto.RatesService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+
+	updates, unsubscribe := healthService.Subscribe()
+	go s.sync(updates, unsubscribe)
+	return s
+}
+
+// RegisterStandard регистрирует стандартный health-сервис в gRPC сервере
+func RegisterStandard(gRPC *grpc.Server, healthService HealthService) *StandardServer {
+	s := NewStandardServer(healthService)
+	healthpb.RegisterHealthServer(gRPC, s)
+	return s
+}
+
+// Watch реализует grpc.health.v1.Health/Watch.
+// В отличие от health.Server поток завершается при остановке HealthService, иначе GracefulStop ждал бы клиентов.
+func (s *StandardServer) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-s.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return s.Server.Watch(req, &watchStream{Health_WatchServer: stream, ctx: ctx})
+}
+
+// sync обновляет статусы при каждом опубликованном состоянии HealthService до закрытия канала
+// и отписывается от HealthService при завершении
+func (s *StandardServer) sync(updates <-chan *models.HealthStatus, unsubscribe func()) {
+	defer unsubscribe()
+
+	for status := range updates {
+		s.update(status)
+	}
+
+	// HealthService остановлен: клиенты должны перестать направлять запросы
+	s.Shutdown()
+	close(s.done)
+}
+
+// update переносит общий статус и статусы компонентов в стандартный health-сервис.
+// Компоненты появляются после первого фонового опроса, до этого Check для них возвращает NotFound.
+func (s *StandardServer) update(status *models.HealthStatus) {
+	// При деградации отдельных компонентов сервис продолжает обслуживать запросы
+	overall := healthpb.HealthCheckResponse_NOT_SERVING
+	if status.Status == models.StatusHealthy || status.Status == models.StatusDegraded {
+		overall = healthpb.HealthCheckResponse_SERVING
+	}
+	s.SetServingStatus("", overall)
+	s.SetServingStatus(usdtproto.RatesService_ServiceDesc.ServiceName, overall)
+
+	for _, c := range status.Components {
+		servingStatus := healthpb.HealthCheckResponse_SERVING
+		if c.Status != models.StatusHealthy {
+			servingStatus = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		s.SetServingStatus(c.Name, servingStatus)
+	}
+}
+
+// watchStream подменяет контекст потока, чтобы завершать Watch при остановке сервиса
+type watchStream struct {
+	healthpb.Health_WatchServer
+	ctx context.Context
+}
+
+func (w *watchStream) Context() context.Context {
+	return w.ctx
+}
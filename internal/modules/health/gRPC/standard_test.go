@@ -0,0 +1,126 @@
+package grpchealth
+
+import (
+	"context"
+	"getUSDT/internal/models"
+	"getUSDT/internal/testutil/grpctest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// switchableHealthService публикует состояния подписчику вручную
+type switchableHealthService struct {
+	stubHealthService
+	updates      chan *models.HealthStatus
+	unsubscribed chan struct{} // Закрывается при отписке
+}
+
+// newSwitchableHealthService создает сервис, у которого уже опубликовано начальное состояние
+func newSwitchableHealthService(status *models.HealthStatus) *switchableHealthService {
+	updates := make(chan *models.HealthStatus, 1)
+	updates <- status
+	return &switchableHealthService{updates: updates, unsubscribed: make(chan struct{})}
+}
+
+func (s *switchableHealthService) Subscribe() (<-chan *models.HealthStatus, func()) {
+	var once sync.Once
+	return s.updates, func() { once.Do(func() { close(s.unsubscribed) }) }
+}
+
+func (s *switchableHealthService) set(status *models.HealthStatus) {
+	s.updates <- status
+}
+
+func newStandardClient(t *testing.T, healthService HealthService) healthpb.HealthClient {
+	t.Helper()
+
+	conn := grpctest.NewBufconnClient(t, func(server *grpc.Server) {
+		RegisterStandard(server, healthService)
+	})
+	return healthpb.NewHealthClient(conn)
+}
+
+func TestStandard_SyncsWithHealthService(t *testing.T) {
+	healthService := newSwitchableHealthService(&models.HealthStatus{
+		Status: models.StatusDegraded,
+		Components: []models.ComponentStatus{
+			{Name: "database", Status: models.StatusHealthy, Critical: true},
+			{Name: "exchange:garantex", Status: models.StatusUnhealthy},
+		},
+	})
+	client := newStandardClient(t, healthService)
+
+	tests := []struct {
+		service string
+		status  healthpb.HealthCheckResponse_ServingStatus
+	}{
+		{service: "", status: healthpb.HealthCheckResponse_SERVING},
+		{service: "usdt.RatesService", status: healthpb.HealthCheckResponse_SERVING},
+		{service: "database", status: healthpb.HealthCheckResponse_SERVING},
+		{service: "exchange:garantex", status: healthpb.HealthCheckResponse_NOT_SERVING},
+	}
+	for _, tt := range tests {
+		assert.Eventually(t, func() bool {
+			resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: tt.service})
+			return err == nil && resp.GetStatus() == tt.status
+		}, time.Second, 10*time.Millisecond, tt.service)
+	}
+
+	healthService.set(&models.HealthStatus{
+		Status:     models.StatusUnhealthy,
+		Components: []models.ComponentStatus{{Name: "database", Status: models.StatusUnhealthy, Critical: true}},
+	})
+	assert.Eventually(t, func() bool {
+		resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+		return err == nil && resp.GetStatus() == healthpb.HealthCheckResponse_NOT_SERVING
+	}, time.Second, 10*time.Millisecond)
+
+	_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: "exchange:binance"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestStandard_WatchEndsOnShutdown(t *testing.T) {
+	healthService := newSwitchableHealthService(&models.HealthStatus{Status: models.StatusHealthy})
+	client := newStandardClient(t, healthService)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	// Первым может прийти NOT_SERVING, если синхронизация еще не выполнена
+	for {
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+		if resp.GetStatus() == healthpb.HealthCheckResponse_SERVING {
+			break
+		}
+	}
+
+	// Остановка HealthService переводит сервис в NOT_SERVING и завершает поток.
+	// Поток может завершиться раньше, чем клиент получит NOT_SERVING.
+	close(healthService.updates)
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			assert.Equal(t, codes.Canceled, status.Code(err))
+			break
+		}
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.GetStatus())
+	}
+
+	// После завершения синхронизации подписка освобождается
+	select {
+	case <-healthService.unsubscribed:
+	case <-ctx.Done():
+		t.Fatal("StandardServer не отписался от HealthService")
+	}
+}
@@ -0,0 +1,147 @@
+package grpchealth
+
+import (
+	"context"
+	"errors"
+	"getUSDT/config"
+	healthservice "getUSDT/internal/modules/health/service"
+	"getUSDT/internal/testutil/grpctest"
+	"getUSDT/proto/health/proto"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newWatchClient поднимает gRPC сервер поверх bufconn с настоящим HealthService
+func newWatchClient(t *testing.T, health *healthservice.HealthService) proto.HealthClient {
+	t.Helper()
+
+	conn := grpctest.NewBufconnClient(t, func(server *grpc.Server) {
+		Register(server, health, noop.NewTracerProvider().Tracer("test"))
+	})
+	return proto.NewHealthClient(conn)
+}
+
+func TestWatch_StreamsTransitions(t *testing.T) {
+	health := healthservice.NewHealthService(config.HealthConfig{
+		CheckTimeout:  50 * time.Millisecond,
+		WatchInterval: 10 * time.Millisecond,
+	})
+	var down atomic.Bool
+	health.AddReadinessChecker("database", healthservice.CheckerFunc(func(context.Context) error {
+		if down.Load() {
+			return errors.New("connection refused")
+		}
+		return nil
+	}))
+	health.Start()
+	defer health.Close()
+
+	client := newWatchClient(t, health)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.Watch(ctx, &proto.HealthCheckRequest{Service: "database"})
+	require.NoError(t, err)
+
+	// Текущий статус приходит сразу после подписки
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, proto.HealthCheckResponse_SERVING, resp.GetStatus())
+
+	down.Store(true)
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, proto.HealthCheckResponse_NOT_SERVING, resp.GetStatus())
+	assert.Equal(t, "connection refused", resp.GetComponents()[0].GetError())
+
+	down.Store(false)
+	resp, err = stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, proto.HealthCheckResponse_SERVING, resp.GetStatus())
+}
+
+func TestWatch_StreamsShareProbes(t *testing.T) {
+	// Фоновый опрос выполняется один раз, следующий — не раньше чем через час
+	health := healthservice.NewHealthService(config.HealthConfig{WatchInterval: time.Hour})
+	var probes atomic.Int32
+	health.AddReadinessChecker("database", healthservice.CheckerFunc(func(context.Context) error {
+		probes.Add(1)
+		return nil
+	}))
+
+	published, unsubscribe := health.Subscribe()
+	defer unsubscribe()
+	health.Start()
+	defer health.Close()
+	<-published
+
+	client := newWatchClient(t, health)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Каждый поток получает текущий статус из опубликованного состояния, не запуская проверки
+	for i := 0; i < 5; i++ {
+		stream, err := client.Watch(ctx, &proto.HealthCheckRequest{Service: "database"})
+		require.NoError(t, err)
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, proto.HealthCheckResponse_SERVING, resp.GetStatus())
+	}
+	assert.Equal(t, int32(1), probes.Load())
+}
+
+func TestWatch_Initializing(t *testing.T) {
+	health := healthservice.NewHealthService(config.HealthConfig{})
+	defer health.Close()
+
+	client := newWatchClient(t, health)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.Watch(ctx, &proto.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, proto.HealthCheckResponse_NOT_SERVING, resp.GetStatus())
+}
+
+func TestWatch_ServiceClosed(t *testing.T) {
+	health := healthservice.NewHealthService(config.HealthConfig{})
+	health.AddChecker("scheduler", healthservice.CheckerFunc(func(context.Context) error { return nil }))
+
+	client := newWatchClient(t, health)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := client.Watch(ctx, &proto.HealthCheckRequest{Service: "scheduler"})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.NoError(t, err)
+
+	// Остановка сервиса завершает поток, не дожидаясь отключения клиента
+	health.Close()
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestWatch_UnknownService(t *testing.T) {
+	health := healthservice.NewHealthService(config.HealthConfig{})
+	defer health.Close()
+
+	client := newWatchClient(t, health)
+
+	stream, err := client.Watch(context.Background(), &proto.HealthCheckRequest{Service: "exchange:binance"})
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,100 @@
+package httphealth
+
+import (
+	"context"
+	"encoding/json"
+	"getUSDT/internal/models"
+	"net/http"
+)
+
+// HealthService источник состояния приложения и его компонентов
+type HealthService interface {
+	CheckHealthStatus(ctx context.Context) (*models.HealthStatus, error)
+}
+
+// Handler отдает состояние приложения по HTTP для инфраструктуры без gRPC клиента
+type Handler struct {
+	healthService HealthService
+}
+
+// NewHandler создаёт новый Handler
+func NewHandler(healthService HealthService) *Handler {
+	return &Handler{healthService: healthService}
+}
+
+// Register регистрирует /livez, /readyz и /healthz в HTTP мультиплексоре
+func Register(mux *http.ServeMux, healthService HealthService) {
+	h := NewHandler(healthService)
+	mux.HandleFunc("GET /livez", h.Livez)
+	mux.HandleFunc("GET /readyz", h.Readyz)
+	mux.HandleFunc("GET /healthz", h.Healthz)
+}
+
+// Livez сообщает, что процесс жив. Компоненты не проверяются,
+// чтобы отказ биржи или базы данных не приводил к перезапуску приложения.
+func (h *Handler) Livez(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, &models.HealthStatus{Status: models.StatusHealthy})
+}
+
+// Readyz сообщает, готово ли приложение обслуживать запросы.
+// Учитываются только критичные компоненты, ответ 503 пока приложение запускается или не готово.
+func (h *Handler) Readyz(w http.ResponseWriter, r *http.Request) {
+	status, err := h.healthService.CheckHealthStatus(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	ready := &models.HealthStatus{Status: status.Status}
+	if status.Status != models.StatusInitializing {
+		ready.Status = models.StatusHealthy
+		for _, c := range status.Components {
+			if !c.Critical {
+				continue
+			}
+			ready.Components = append(ready.Components, c)
+			if c.Status != models.StatusHealthy {
+				ready.Status = models.StatusUnhealthy
+			}
+		}
+	}
+
+	code := http.StatusOK
+	if ready.Status != models.StatusHealthy {
+		code = http.StatusServiceUnavailable
+	}
+	writeJSON(w, code, ready)
+}
+
+// Healthz возвращает общий статус и состояние всех компонентов.
+// При деградации отдельных компонентов сервис продолжает обслуживать запросы, поэтому ответ 200.
+func (h *Handler) Healthz(w http.ResponseWriter, r *http.Request) {
+	status, err := h.healthService.CheckHealthStatus(r.Context())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	code := http.StatusServiceUnavailable
+	if status.Status == models.StatusHealthy || status.Status == models.StatusDegraded {
+		code = http.StatusOK
+	}
+	writeJSON(w, code, status)
+}
+
+// errorResponse тело ответа, когда состояние получить не удалось
+type errorResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	writeJSON(w, http.StatusServiceUnavailable, errorResponse{Status: models.StatusUnhealthy, Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
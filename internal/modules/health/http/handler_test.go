@@ -0,0 +1,104 @@
+package httphealth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"getUSDT/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubHealthService возвращает заранее заданное состояние
+type stubHealthService struct {
+	status *models.HealthStatus
+	err    error
+}
+
+func (s stubHealthService) CheckHealthStatus(context.Context) (*models.HealthStatus, error) {
+	return s.status, s.err
+}
+
+func serve(t *testing.T, healthService HealthService, path string) (int, models.HealthStatus) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	Register(mux, healthService)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var body models.HealthStatus
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	return rec.Code, body
+}
+
+func TestHandler_Endpoints(t *testing.T) {
+	degraded := &models.HealthStatus{
+		Status: models.StatusDegraded,
+		Components: []models.ComponentStatus{
+			{Name: "database", Status: models.StatusHealthy, Critical: true},
+			{Name: "exchange:garantex", Status: models.StatusUnhealthy, Error: "circuit breaker is open"},
+		},
+	}
+	unhealthy := &models.HealthStatus{
+		Status: models.StatusUnhealthy,
+		Components: []models.ComponentStatus{
+			{Name: "database", Status: models.StatusUnhealthy, Error: "connection refused", Critical: true},
+		},
+	}
+	initializing := &models.HealthStatus{Status: models.StatusInitializing}
+
+	tests := []struct {
+		name       string
+		path       string
+		status     *models.HealthStatus
+		code       int
+		body       string
+		components []string
+	}{
+		{name: "livez ignores components", path: "/livez", status: unhealthy, code: http.StatusOK, body: models.StatusHealthy},
+		{name: "readyz degraded", path: "/readyz", status: degraded, code: http.StatusOK, body: models.StatusHealthy, components: []string{"database"}},
+		{name: "readyz unhealthy", path: "/readyz", status: unhealthy, code: http.StatusServiceUnavailable, body: models.StatusUnhealthy, components: []string{"database"}},
+		{name: "readyz initializing", path: "/readyz", status: initializing, code: http.StatusServiceUnavailable, body: models.StatusInitializing},
+		{name: "healthz degraded", path: "/healthz", status: degraded, code: http.StatusOK, body: models.StatusDegraded, components: []string{"database", "exchange:garantex"}},
+		{name: "healthz unhealthy", path: "/healthz", status: unhealthy, code: http.StatusServiceUnavailable, body: models.StatusUnhealthy, components: []string{"database"}},
+		{name: "healthz initializing", path: "/healthz", status: initializing, code: http.StatusServiceUnavailable, body: models.StatusInitializing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, body := serve(t, stubHealthService{status: tt.status}, tt.path)
+
+			assert.Equal(t, tt.code, code)
+			assert.Equal(t, tt.body, body.Status)
+			var names []string
+			for _, c := range body.Components {
+				names = append(names, c.Name)
+			}
+			assert.Equal(t, tt.components, names)
+		})
+	}
+}
+
+func TestHandler_CheckError(t *testing.T) {
+	code, body := serve(t, stubHealthService{err: errors.New("health service is nil")}, "/healthz")
+
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, models.StatusUnhealthy, body.Status)
+}
+
+func TestHandler_MethodNotAllowed(t *testing.T) {
+	mux := http.NewServeMux()
+	Register(mux, stubHealthService{status: &models.HealthStatus{Status: models.StatusHealthy}})
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/readyz", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
@@ -3,24 +3,86 @@ package healthservice
 import (
 	"context"
 	"fmt"
+	"getUSDT/config"
 	"getUSDT/internal/models"
 	"log"
+	"sort"
+	"sync"
 	"time"
 )
 
+const defaultCheckTimeout = 2 * time.Second
+
+// Checker проверяет состояние отдельного компонента приложения
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc позволяет использовать функцию в качестве Checker
+type CheckerFunc func(ctx context.Context) error
+
+// Check вызывает f(ctx)
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// component зарегистрированная проверка компонента
+type component struct {
+	checker  Checker
+	critical bool
+}
+
 // HealthService структура для реализации HealthService
 type HealthService struct {
-	startTime time.Time
+	startTime     time.Time
+	checkTimeout  time.Duration
+	watchInterval time.Duration
+
+	mu         sync.RWMutex
+	components map[string]component
+
+	watch watchers
 }
 
 // NewHealthService создаёт новый экземпляр HealthService
-func NewHealthService() *HealthService {
+func NewHealthService(cfg config.HealthConfig) *HealthService {
+	checkTimeout := cfg.CheckTimeout
+	if checkTimeout <= 0 {
+		checkTimeout = defaultCheckTimeout
+	}
+	watchInterval := cfg.WatchInterval
+	if watchInterval <= 0 {
+		watchInterval = defaultWatchInterval
+	}
+
 	// Инициализируем startTime, чтобы отслеживать время работы приложения
 	return &HealthService{
-		startTime: time.Now(),
+		startTime:     time.Now(),
+		checkTimeout:  checkTimeout,
+		watchInterval: watchInterval,
+		components:    make(map[string]component),
+		watch:         watchers{subscribers: make(map[chan *models.HealthStatus]struct{})},
 	}
 }
 
+// AddChecker регистрирует проверку компонента под указанным именем.
+// Отказ такой проверки делает статус "Degraded".
+func (h *HealthService) AddChecker(name string, c Checker) {
+	h.register(name, c, false)
+}
+
+// AddReadinessChecker регистрирует проверку, без которой приложение не готово обслуживать запросы.
+// Отказ такой проверки делает статус "Unhealthy".
+func (h *HealthService) AddReadinessChecker(name string, c Checker) {
+	h.register(name, c, true)
+}
+
+func (h *HealthService) register(name string, c Checker, critical bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.components[name] = component{checker: c, critical: critical}
+}
+
 // CheckHealthStatus проверяет статус здоровья приложения
 func (h *HealthService) CheckHealthStatus(ctx context.Context) (*models.HealthStatus, error) {
 	// Проверка на nil
@@ -33,17 +95,103 @@ func (h *HealthService) CheckHealthStatus(ctx context.Context) (*models.HealthSt
 	case <-ctx.Done():
 		return nil, ctx.Err() // Если контекст отменен, возвращаем ошибку
 	default:
-		healthyDuration := time.Since(h.startTime)
-		if healthyDuration < time.Second*5 {
+		if h.initializing() {
 			// Если приложение только что запустилось, статус может быть "Initializing"
 			return &models.HealthStatus{
-				Status: "Initializing",
+				Status: models.StatusInitializing,
 			}, nil
 		}
 
-		// Если прошло достаточно времени, приложение считается "Healthy"
+		// Если прошло достаточно времени, статус определяется проверками компонентов
+		components := h.checkComponents(ctx)
 		return &models.HealthStatus{
-			Status: "Healthy",
+			Status:     aggregate(components),
+			Components: components,
 		}, nil
 	}
 }
+
+// initializing сообщает, что приложение запущено недавно и еще не готово сообщать статус
+func (h *HealthService) initializing() bool {
+	return time.Since(h.startTime) < time.Second*5
+}
+
+// CheckComponent проверяет состояние одного компонента по имени.
+// Для незарегистрированного имени возвращает models.ErrComponentNotFound.
+func (h *HealthService) CheckComponent(ctx context.Context, name string) (*models.ComponentStatus, error) {
+	h.mu.RLock()
+	c, ok := h.components[name]
+	h.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", models.ErrComponentNotFound, name)
+	}
+
+	status := h.checkComponent(ctx, name, c)
+	return &status, nil
+}
+
+// aggregate сводит состояния компонентов в общий статус:
+// отказ критичного компонента делает приложение "Unhealthy", остальных — "Degraded"
+func aggregate(components []models.ComponentStatus) string {
+	status := models.StatusHealthy
+	for _, c := range components {
+		switch {
+		case c.Status == models.StatusHealthy:
+		case c.Critical:
+			return models.StatusUnhealthy
+		default:
+			status = models.StatusDegraded
+		}
+	}
+	return status
+}
+
+// checkComponents параллельно выполняет зарегистрированные проверки и возвращает их результаты в порядке имен.
+// Каждая проверка ограничена таймаутом, чтобы зависший компонент не блокировал ответ.
+func (h *HealthService) checkComponents(ctx context.Context) []models.ComponentStatus {
+	h.mu.RLock()
+	names := make([]string, 0, len(h.components))
+	registered := make(map[string]component, len(h.components))
+	for name, c := range h.components {
+		names = append(names, name)
+		registered[name] = c
+	}
+	h.mu.RUnlock()
+	sort.Strings(names)
+
+	statuses := make([]models.ComponentStatus, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string, c component) {
+			defer wg.Done()
+			statuses[i] = h.checkComponent(ctx, name, c)
+		}(i, name, registered[name])
+	}
+	wg.Wait()
+
+	return statuses
+}
+
+// checkComponent выполняет проверку одного компонента с таймаутом
+func (h *HealthService) checkComponent(ctx context.Context, name string, c component) models.ComponentStatus {
+	ctx, cancel := context.WithTimeout(ctx, h.checkTimeout)
+	defer cancel()
+
+	status := models.ComponentStatus{Name: name, Status: models.StatusHealthy, Critical: c.critical}
+
+	done := make(chan error, 1)
+	go func() { done <- c.checker.Check(ctx) }()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("check timed out: %w", ctx.Err())
+	}
+	if err != nil {
+		status.Status = models.StatusUnhealthy
+		status.Error = err.Error()
+	}
+	return status
+}
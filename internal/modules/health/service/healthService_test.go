@@ -0,0 +1,81 @@
+package healthservice
+
+import (
+	"context"
+	"errors"
+	"getUSDT/config"
+	"getUSDT/internal/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newStartedHealthService создает сервис, уже завершивший инициализацию
+func newStartedHealthService() *HealthService {
+	h := NewHealthService(config.HealthConfig{CheckTimeout: 50 * time.Millisecond})
+	h.startTime = time.Now().Add(-time.Minute)
+	return h
+}
+
+func ok(context.Context) error { return nil }
+
+func failing(context.Context) error { return errors.New("connection refused") }
+
+func TestCheckHealthStatus_Initializing(t *testing.T) {
+	h := NewHealthService(config.HealthConfig{})
+
+	status, err := h.CheckHealthStatus(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.StatusInitializing, status.Status)
+}
+
+func TestCheckHealthStatus_Aggregation(t *testing.T) {
+	tests := []struct {
+		name     string
+		database CheckerFunc
+		exchange CheckerFunc
+		status   string
+	}{
+		{name: "all healthy", database: ok, exchange: ok, status: models.StatusHealthy},
+		{name: "exchange down", database: ok, exchange: failing, status: models.StatusDegraded},
+		{name: "database down", database: failing, exchange: ok, status: models.StatusUnhealthy},
+		{name: "everything down", database: failing, exchange: failing, status: models.StatusUnhealthy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newStartedHealthService()
+			h.AddReadinessChecker("database", tt.database)
+			h.AddChecker("exchange:garantex", tt.exchange)
+
+			status, err := h.CheckHealthStatus(context.Background())
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, status.Status)
+			assert.Len(t, status.Components, 2)
+		})
+	}
+}
+
+func TestCheckHealthStatus_ComponentDetails(t *testing.T) {
+	h := newStartedHealthService()
+	h.AddReadinessChecker("database", CheckerFunc(failing))
+	// Зависшая проверка прерывается по таймауту
+	h.AddChecker("scheduler", CheckerFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(time.Second)
+		return nil
+	}))
+
+	start := time.Now()
+	status, err := h.CheckHealthStatus(context.Background())
+
+	assert.NoError(t, err)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, []models.ComponentStatus{
+		{Name: "database", Status: models.StatusUnhealthy, Error: "connection refused", Critical: true},
+		{Name: "scheduler", Status: models.StatusUnhealthy, Error: "check timed out: context deadline exceeded"},
+	}, status.Components)
+}
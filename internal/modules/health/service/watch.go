@@ -0,0 +1,162 @@
+package healthservice
+
+import (
+	"context"
+	"getUSDT/internal/models"
+	"sync"
+	"time"
+)
+
+const defaultWatchInterval = 5 * time.Second
+
+// watchers подписчики на изменения состояния и фоновый опрос компонентов
+type watchers struct {
+	mu          sync.Mutex
+	subscribers map[chan *models.HealthStatus]struct{}
+	closed      bool
+	last        map[string]string    // Последние известные статусы: общий под пустым именем и компонентов
+	snapshot    *models.HealthStatus // Последнее опубликованное состояние
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
+}
+
+// Start запускает фоновый опрос компонентов, который уведомляет подписчиков об изменениях статуса
+func (h *HealthService) Start() {
+	h.watch.mu.Lock()
+	defer h.watch.mu.Unlock()
+
+	// Повторный запуск и запуск после остановки ничего не делают
+	if h.watch.cancel != nil || h.watch.closed {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	h.watch.cancel = cancel
+	h.watch.wg.Add(1)
+	go h.monitor(ctx)
+}
+
+// Close останавливает фоновый опрос и закрывает каналы всех подписчиков
+func (h *HealthService) Close() {
+	h.watch.mu.Lock()
+	cancel := h.watch.cancel
+	h.watch.cancel = nil
+	if !h.watch.closed {
+		h.watch.closed = true
+		for ch := range h.watch.subscribers {
+			delete(h.watch.subscribers, ch)
+			close(ch)
+		}
+	}
+	h.watch.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		h.watch.wg.Wait()
+	}
+}
+
+// Subscribe возвращает канал состояний приложения и функцию отписки.
+// Состояние, рассчитанное фоновым опросом, публикуется при каждом изменении статуса приложения
+// или любого из компонентов; если опрос уже выполнялся, последнее состояние сразу доступно в канале.
+// Подписчик, не успевающий читать, получает только самое свежее состояние.
+// Канал закрывается после отписки или Close.
+func (h *HealthService) Subscribe() (<-chan *models.HealthStatus, func()) {
+	ch := make(chan *models.HealthStatus, 1)
+
+	h.watch.mu.Lock()
+	defer h.watch.mu.Unlock()
+
+	// После остановки новые подписчики сразу получают закрытый канал
+	if h.watch.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	h.watch.subscribers[ch] = struct{}{}
+	if h.watch.snapshot != nil {
+		ch <- h.watch.snapshot
+	}
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			h.watch.mu.Lock()
+			defer h.watch.mu.Unlock()
+			if _, ok := h.watch.subscribers[ch]; ok {
+				delete(h.watch.subscribers, ch)
+				close(ch)
+			}
+		})
+	}
+}
+
+// monitor периодически выполняет проверки и уведомляет подписчиков об изменениях
+func (h *HealthService) monitor(ctx context.Context) {
+	defer h.watch.wg.Done()
+
+	ticker := time.NewTicker(h.watchInterval)
+	defer ticker.Stop()
+
+	h.poll(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.poll(ctx)
+		}
+	}
+}
+
+// poll выполняет проверки и публикует состояние подписчикам, если хотя бы один статус изменился
+func (h *HealthService) poll(ctx context.Context) {
+	// Компоненты проверяются и во время инициализации, чтобы потоки отдельных компонентов получали изменения
+	components := h.checkComponents(ctx)
+	if ctx.Err() != nil {
+		return
+	}
+	status := aggregate(components)
+	if h.initializing() {
+		status = models.StatusInitializing
+	}
+	current := snapshot(status, components)
+
+	h.watch.mu.Lock()
+	defer h.watch.mu.Unlock()
+
+	if equalSnapshots(h.watch.last, current) {
+		return
+	}
+	h.watch.last = current
+	h.watch.snapshot = &models.HealthStatus{Status: status, Components: components}
+	for ch := range h.watch.subscribers {
+		// Непрочитанное состояние заменяется новым; отправляет только poll, поэтому место в канале есть
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- h.watch.snapshot
+	}
+}
+
+// snapshot собирает общий статус и статусы компонентов в одну карту
+func snapshot(status string, components []models.ComponentStatus) map[string]string {
+	statuses := make(map[string]string, len(components)+1)
+	statuses[""] = status
+	for _, c := range components {
+		statuses[c.Name] = c.Status
+	}
+	return statuses
+}
+
+func equalSnapshots(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, status := range a {
+		if b[name] != status {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,93 @@
+package healthservice
+
+import (
+	"context"
+	"errors"
+	"getUSDT/config"
+	"getUSDT/internal/models"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscribe_NotifiesOnTransition(t *testing.T) {
+	h := NewHealthService(config.HealthConfig{CheckTimeout: 50 * time.Millisecond, WatchInterval: 10 * time.Millisecond})
+	h.startTime = time.Now().Add(-time.Minute)
+
+	var down atomic.Bool
+	h.AddReadinessChecker("database", CheckerFunc(func(context.Context) error {
+		if down.Load() {
+			return errors.New("connection refused")
+		}
+		return nil
+	}))
+
+	updates, unsubscribe := h.Subscribe()
+	defer unsubscribe()
+	h.Start()
+	defer h.Close()
+
+	// Первый опрос публикует начальное состояние
+	status := requireStatus(t, updates)
+	assert.Equal(t, models.StatusHealthy, status.Components[0].Status)
+
+	// Без изменений подписчик не получает сигналов
+	select {
+	case <-updates:
+		t.Fatal("unexpected notification without status change")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	down.Store(true)
+	status = requireStatus(t, updates)
+	assert.Equal(t, models.StatusUnhealthy, status.Status)
+	assert.Equal(t, "connection refused", status.Components[0].Error)
+
+	// Новый подписчик сразу получает последнее опубликованное состояние
+	late, unsubscribeLate := h.Subscribe()
+	defer unsubscribeLate()
+	assert.Same(t, status, requireStatus(t, late))
+}
+
+func TestClose_ClosesSubscribers(t *testing.T) {
+	h := NewHealthService(config.HealthConfig{WatchInterval: 10 * time.Millisecond})
+	h.Start()
+
+	updates, _ := h.Subscribe()
+	h.Close()
+
+	// Канал закрывается, но в нем может остаться последнее состояние
+	for range updates {
+	}
+
+	late, _ := h.Subscribe()
+	_, ok := <-late
+	assert.False(t, ok)
+}
+
+func TestUnsubscribe_Idempotent(t *testing.T) {
+	h := NewHealthService(config.HealthConfig{})
+
+	updates, unsubscribe := h.Subscribe()
+	unsubscribe()
+	unsubscribe()
+	h.Close()
+
+	_, ok := <-updates
+	assert.False(t, ok)
+}
+
+func requireStatus(t *testing.T, updates <-chan *models.HealthStatus) *models.HealthStatus {
+	t.Helper()
+	select {
+	case status, ok := <-updates:
+		require.True(t, ok)
+		return status
+	case <-time.After(time.Second):
+		t.Fatal("no notification received")
+		return nil
+	}
+}
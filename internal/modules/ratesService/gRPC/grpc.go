@@ -2,13 +2,18 @@ package grpcrates
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"getUSDT/internal/models"
 	"getUSDT/proto/usdt/proto"
+	"time"
 
+	"github.com/shopspring/decimal"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type RatesServer struct {
@@ -18,8 +23,12 @@ type RatesServer struct {
 }
 
 type RatesService interface {
-	GetRatesFromAPI(ctx context.Context) (*models.Rate, error)
-	SaveRate(ctx context.Context, rate *models.Rate) error
+	GetRates(ctx context.Context, market string) (*models.Rate, error)
+	GetRateHistory(ctx context.Context, market string, from, to time.Time, limit int, pageToken string) (*models.RateHistoryPage, error)
+	SubscribeRates() (<-chan *models.Rate, func())
+	GetCandles(ctx context.Context, market, interval string, from, to time.Time) ([]models.Candle, error)
+	ListMarkets() []models.Market
+	GetQuote(ctx context.Context, market, side string, amount decimal.Decimal) (*models.Quote, error)
 }
 
 func NewRatesServer(ratesService RatesService, tr trace.Tracer) *RatesServer {
@@ -47,33 +56,302 @@ func (s *RatesServer) GetRates(ctx context.Context, req *proto.GetRatesRequest)
 	)
 
 	// Получаем последний курс через сервис
-	rate, err := s.ratesService.GetRatesFromAPI(ctx)
+	rate, err := s.ratesService.GetRates(ctx, req.GetMarket())
 	if err != nil {
 		// Добавляем атрибуты ошибки к спану
 		span.RecordError(err)
-		span.SetAttributes(attribute.String("error", "failed to fetch rate from API"))
-		return nil, fmt.Errorf("failed to fetch rate from API: %w", err)
-	}
-
-	// Сохраняем курс
-	if err := s.ratesService.SaveRate(ctx, rate); err != nil {
-		// Добавляем атрибуты ошибки к спану
-		span.RecordError(err)
-		span.SetAttributes(attribute.String("error", "failed to save rate"))
-		return nil, fmt.Errorf("failed to save rate: %w", err)
+		span.SetAttributes(attribute.String("error", "failed to get rate"))
+		return nil, toStatusError(err, "failed to get rate")
 	}
 
 	// Добавляем атрибуты успешного результата
 	span.SetAttributes(
-		attribute.Float64("rate.ask", rate.Ask),
-		attribute.Float64("rate.bid", rate.Bid),
+		attribute.String("rate.market", rate.Market),
+		attribute.String("rate.ask", rate.Ask.String()),
+		attribute.String("rate.bid", rate.Bid.String()),
 		attribute.Int64("rate.timestamp", rate.Timestamp.Unix()),
 	)
 
 	// Возвращаем данные через gRPC
-	return &proto.GetRatesResponse{
-		Ask:       rate.Ask,
-		Bid:       rate.Bid,
-		Timestamp: rate.Timestamp.Unix(),
+	return toRatesResponse(rate), nil
+}
+
+// GetRateHistory возвращает историю сохраненных курсов за период.
+func (s *RatesServer) GetRateHistory(ctx context.Context, req *proto.GetRateHistoryRequest) (*proto.GetRateHistoryResponse, error) {
+	ctx, span := s.tr.Start(ctx, "GetRateHistory")
+	defer span.End()
+
+	// Добавляем атрибуты запроса к спану
+	span.SetAttributes(
+		attribute.String("rpc.method", "GetRateHistory"),
+		attribute.String("rpc.system", "grpc"),
+		attribute.String("rpc.service", "RatesService"),
+	)
+
+	// Нулевая граница периода означает "без ограничения"
+	var from, to time.Time
+	if req.GetFrom() != 0 {
+		from = time.Unix(req.GetFrom(), 0)
+	}
+	if req.GetTo() != 0 {
+		to = time.Unix(req.GetTo(), 0)
+	}
+
+	page, err := s.ratesService.GetRateHistory(ctx, req.GetMarket(), from, to, int(req.GetLimit()), req.GetPageToken())
+	if err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("error", "failed to get rate history"))
+		return nil, toStatusError(err, "failed to get rate history")
+	}
+
+	rates := make([]*proto.Rate, 0, len(page.Rates))
+	for _, rate := range page.Rates {
+		rates = append(rates, &proto.Rate{
+			Id:        rate.ID,
+			Market:    rate.Market,
+			Source:    rate.Source,
+			Sources:   rate.Sources,
+			Flags:     rate.Flags,
+			Ask:       rate.Ask.InexactFloat64(),
+			Bid:       rate.Bid.InexactFloat64(),
+			AskExact:  rate.Ask.String(),
+			BidExact:  rate.Bid.String(),
+			Timestamp: rate.Timestamp.Unix(),
+		})
+	}
+
+	span.SetAttributes(attribute.Int("history.count", len(rates)))
+
+	return &proto.GetRateHistoryResponse{
+		Rates:         rates,
+		NextPageToken: page.NextPageToken,
+	}, nil
+}
+
+// StreamRates отправляет клиенту текущий курс, а затем каждый новый сохраненный курс.
+func (s *RatesServer) StreamRates(req *proto.StreamRatesRequest, stream proto.RatesService_StreamRatesServer) error {
+	ctx, span := s.tr.Start(stream.Context(), "StreamRates")
+	defer span.End()
+
+	// Добавляем атрибуты запроса к спану
+	span.SetAttributes(
+		attribute.String("rpc.method", "StreamRates"),
+		attribute.String("rpc.system", "grpc"),
+		attribute.String("rpc.service", "RatesService"),
+	)
+
+	// Подписываемся до получения текущего курса, чтобы не пропустить обновления
+	rates, unsubscribe := s.ratesService.SubscribeRates()
+	defer unsubscribe()
+
+	rate, err := s.ratesService.GetRates(ctx, req.GetMarket())
+	if err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("error", "failed to get rate"))
+		return toStatusError(err, "failed to get rate")
+	}
+	if err := stream.Send(toRatesResponse(rate)); err != nil {
+		return err
+	}
+
+	// Отправляем только курсы запрошенного рынка
+	market := rate.Market
+	span.SetAttributes(attribute.String("rate.market", market))
+
+	sent := 1
+	for {
+		select {
+		case <-ctx.Done():
+			span.SetAttributes(attribute.Int("stream.sent", sent))
+			return nil
+		case rate, ok := <-rates:
+			// Канал закрывается при остановке сервиса
+			if !ok {
+				span.SetAttributes(attribute.Int("stream.sent", sent))
+				return status.Error(codes.Unavailable, "rates stream closed")
+			}
+			if rate.Market != market {
+				continue
+			}
+			if err := stream.Send(toRatesResponse(rate)); err != nil {
+				span.RecordError(err)
+				return err
+			}
+			sent++
+		}
+	}
+}
+
+// GetCandles возвращает свечи по сохраненным курсам за период.
+func (s *RatesServer) GetCandles(ctx context.Context, req *proto.GetCandlesRequest) (*proto.GetCandlesResponse, error) {
+	ctx, span := s.tr.Start(ctx, "GetCandles")
+	defer span.End()
+
+	// Добавляем атрибуты запроса к спану
+	span.SetAttributes(
+		attribute.String("rpc.method", "GetCandles"),
+		attribute.String("rpc.system", "grpc"),
+		attribute.String("rpc.service", "RatesService"),
+	)
+
+	// Нулевой конец периода означает текущий момент, нулевое начало — последние свечи до конца периода
+	var from, to time.Time
+	if req.GetFrom() != 0 {
+		from = time.Unix(req.GetFrom(), 0)
+	}
+	if req.GetTo() != 0 {
+		to = time.Unix(req.GetTo(), 0)
+	}
+
+	candles, err := s.ratesService.GetCandles(ctx, req.GetMarket(), req.GetInterval(), from, to)
+	if err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("error", "failed to get candles"))
+		return nil, toStatusError(err, "failed to get candles")
+	}
+
+	resp := &proto.GetCandlesResponse{Candles: make([]*proto.Candle, 0, len(candles))}
+	for _, candle := range candles {
+		resp.Candles = append(resp.Candles, &proto.Candle{
+			Timestamp: candle.Start.Unix(),
+			Ask:       toProtoOHLC(candle.Ask),
+			Bid:       toProtoOHLC(candle.Bid),
+			Mid:       toProtoOHLC(candle.Mid),
+			Count:     candle.Count,
+		})
+	}
+
+	span.SetAttributes(attribute.Int("candles.count", len(resp.Candles)))
+	return resp, nil
+}
+
+// ListMarkets возвращает включенные рынки.
+func (s *RatesServer) ListMarkets(ctx context.Context, req *proto.ListMarketsRequest) (*proto.ListMarketsResponse, error) {
+	_, span := s.tr.Start(ctx, "ListMarkets")
+	defer span.End()
+
+	// Добавляем атрибуты запроса к спану
+	span.SetAttributes(
+		attribute.String("rpc.method", "ListMarkets"),
+		attribute.String("rpc.system", "grpc"),
+		attribute.String("rpc.service", "RatesService"),
+	)
+
+	markets := s.ratesService.ListMarkets()
+	resp := &proto.ListMarketsResponse{Markets: make([]*proto.Market, 0, len(markets))}
+	for _, market := range markets {
+		resp.Markets = append(resp.Markets, &proto.Market{
+			Name:      market.Name,
+			Providers: market.Providers,
+		})
+	}
+
+	return resp, nil
+}
+
+// GetQuote рассчитывает цену исполнения заданного объема по глубине стакана.
+func (s *RatesServer) GetQuote(ctx context.Context, req *proto.GetQuoteRequest) (*proto.GetQuoteResponse, error) {
+	ctx, span := s.tr.Start(ctx, "GetQuote")
+	defer span.End()
+
+	// Добавляем атрибуты запроса к спану
+	span.SetAttributes(
+		attribute.String("rpc.method", "GetQuote"),
+		attribute.String("rpc.system", "grpc"),
+		attribute.String("rpc.service", "RatesService"),
+	)
+
+	// Преобразуем параметры запроса
+	var side string
+	switch req.GetSide() {
+	case proto.GetQuoteRequest_BUY:
+		side = models.QuoteSideBuy
+	case proto.GetQuoteRequest_SELL:
+		side = models.QuoteSideSell
+	default:
+		return nil, status.Error(codes.InvalidArgument, "side must be BUY or SELL")
+	}
+	amount, err := decimal.NewFromString(req.GetAmount())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid amount %q", req.GetAmount())
+	}
+
+	quote, err := s.ratesService.GetQuote(ctx, req.GetMarket(), side, amount)
+	if err != nil {
+		span.RecordError(err)
+		span.SetAttributes(attribute.String("error", "failed to get quote"))
+		return nil, toStatusError(err, "failed to get quote")
+	}
+
+	return &proto.GetQuoteResponse{
+		Market:          quote.Market,
+		Source:          quote.Source,
+		Side:            req.GetSide(),
+		Amount:          quote.Amount.String(),
+		Filled:          quote.Filled.String(),
+		Cost:            quote.Cost.String(),
+		AveragePrice:    quote.AveragePrice.String(),
+		TopPrice:        quote.TopPrice.String(),
+		SlippageBps:     quote.SlippageBps.String(),
+		LevelsUsed:      int32(quote.LevelsUsed),
+		EnoughLiquidity: quote.EnoughLiquidity,
+		Timestamp:       quote.Timestamp.Unix(),
 	}, nil
 }
+
+// toProtoOHLC преобразует OHLC в сообщение gRPC
+func toProtoOHLC(ohlc models.OHLC) *proto.OHLC {
+	return &proto.OHLC{
+		Open:       ohlc.Open.InexactFloat64(),
+		High:       ohlc.High.InexactFloat64(),
+		Low:        ohlc.Low.InexactFloat64(),
+		Close:      ohlc.Close.InexactFloat64(),
+		OpenExact:  ohlc.Open.String(),
+		HighExact:  ohlc.High.String(),
+		LowExact:   ohlc.Low.String(),
+		CloseExact: ohlc.Close.String(),
+	}
+}
+
+// toRatesResponse преобразует курс в ответ gRPC
+func toRatesResponse(rate *models.Rate) *proto.GetRatesResponse {
+	resp := &proto.GetRatesResponse{
+		Ask:       rate.Ask.InexactFloat64(),
+		Bid:       rate.Bid.InexactFloat64(),
+		AskExact:  rate.Ask.String(),
+		BidExact:  rate.Bid.String(),
+		Timestamp: rate.Timestamp.Unix(),
+		Market:    rate.Market,
+		Source:    rate.Source,
+		Sources:   rate.Sources,
+		Flags:     rate.Flags,
+		AgeMs:     rate.Age.Milliseconds(),
+		IsStale:   rate.Stale,
+	}
+	if !rate.ExchangeTime.IsZero() {
+		resp.ExchangeTimestamp = rate.ExchangeTime.Unix()
+	}
+	return resp
+}
+
+// toStatusError преобразует ошибку сервиса в gRPC статус.
+// Недоступность биржи проверяется раньше ошибок контекста: таймаут провайдера — это сбой биржи,
+// а не истекший дедлайн клиента.
+func toStatusError(err error, msg string) error {
+	switch {
+	case errors.Is(err, models.ErrProviderUnavailable):
+		return status.Error(codes.Unavailable, fmt.Sprintf("%s: %v", msg, err))
+	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	case errors.Is(err, models.ErrInvalidArgument):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, models.ErrRateNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, models.ErrRateRejected):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, models.ErrOrderBookUnavailable):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, fmt.Sprintf("%s: %v", msg, err))
+	}
+}
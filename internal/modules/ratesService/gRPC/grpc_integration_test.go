@@ -0,0 +1,176 @@
+package grpcrates
+
+import (
+	"context"
+	"getUSDT/config"
+	"getUSDT/internal/modules/ratesService/provider"
+	"getUSDT/internal/modules/ratesService/service"
+	"getUSDT/internal/modules/ratesService/service/mocks"
+	"getUSDT/internal/testutil/fakegarantex"
+	"getUSDT/internal/testutil/grpctest"
+	"getUSDT/proto/usdt/proto"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newIntegrationClient поднимает RatesServer поверх поддельной биржи и возвращает gRPC клиент,
+// подключенный к нему через соединение в памяти
+func newIntegrationClient(t *testing.T, exchange *fakegarantex.Server) proto.RatesServiceClient {
+	t.Helper()
+	return newRatesClient(t, newTestGarantex(t, exchange))
+}
+
+// newTestGarantex создает провайдера Garantex, обращающегося к поддельной бирже
+func newTestGarantex(t *testing.T, exchange *fakegarantex.Server) provider.RateProvider {
+	t.Helper()
+
+	garantex, err := provider.NewGarantex(config.ProviderConfig{
+		Name:    provider.GarantexName,
+		URL:     exchange.BaseURL(),
+		Timeout: 500 * time.Millisecond,
+		Markets: []string{"usdtrub"},
+	})
+	require.NoError(t, err)
+	return garantex
+}
+
+// newRatesClient поднимает RatesServer с указанным провайдером и возвращает gRPC клиент
+func newRatesClient(t *testing.T, rateProvider provider.RateProvider) proto.RatesServiceClient {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockRatesStorage(ctrl)
+	storage.EXPECT().SaveRate(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	ratesService := service.NewRatesService(storage, rateProvider, config.RatesConfig{}, nil)
+
+	conn := grpctest.NewBufconnClient(t, func(server *grpc.Server) {
+		Register(server, ratesService, noop.NewTracerProvider().Tracer("test"))
+	})
+	return proto.NewRatesServiceClient(conn)
+}
+
+func TestIntegration_GetRates_PriceSequence(t *testing.T) {
+	exchange := fakegarantex.New()
+	defer exchange.Close()
+	exchange.Enqueue("usdtrub", fakegarantex.PriceSequence("100.15", "99.85", "101.20", "100.90")...)
+
+	client := newIntegrationClient(t, exchange)
+
+	// Каждый вызов получает очередной стакан биржи
+	first, err := client.GetRates(context.Background(), &proto.GetRatesRequest{Market: "usdtrub"})
+	require.NoError(t, err)
+	assert.Equal(t, "100.15", first.GetAskExact())
+	assert.Equal(t, "99.85", first.GetBidExact())
+	assert.Equal(t, provider.GarantexName, first.GetSource())
+	assert.NotZero(t, first.GetExchangeTimestamp())
+	assert.False(t, first.GetIsStale())
+
+	second, err := client.GetRates(context.Background(), &proto.GetRatesRequest{Market: "usdtrub"})
+	require.NoError(t, err)
+	assert.Equal(t, "101.2", second.GetAskExact())
+	assert.Equal(t, "100.9", second.GetBidExact())
+
+	assert.Equal(t, 2, exchange.Requests("usdtrub"))
+}
+
+func TestIntegration_GetRates_ExchangeFailures(t *testing.T) {
+	// Сбой биржи означает временную недоступность сервиса, а не внутреннюю ошибку
+	tests := []struct {
+		name     string
+		response fakegarantex.Response
+	}{
+		{name: "empty book", response: fakegarantex.EmptyBook()},
+		{name: "malformed json", response: fakegarantex.Malformed()},
+		{name: "server error", response: fakegarantex.Status(http.StatusInternalServerError)},
+		{name: "slow response", response: fakegarantex.Slow(2*time.Second, fakegarantex.Prices("100", "99"))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exchange := fakegarantex.New()
+			defer exchange.Close()
+			exchange.Enqueue("usdtrub", tt.response)
+
+			client := newIntegrationClient(t, exchange)
+
+			_, err := client.GetRates(context.Background(), &proto.GetRatesRequest{Market: "usdtrub"})
+
+			assert.Equal(t, codes.Unavailable, status.Code(err))
+		})
+	}
+}
+
+func TestIntegration_GetRates_ClientDeadline(t *testing.T) {
+	exchange := fakegarantex.New()
+	defer exchange.Close()
+	exchange.Enqueue("usdtrub", fakegarantex.Slow(2*time.Second, fakegarantex.Prices("100", "99")))
+
+	client := newIntegrationClient(t, exchange)
+
+	// Дедлайн клиента короче таймаута провайдера и прерывает ожидание биржи
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.GetRates(ctx, &proto.GetRatesRequest{Market: "usdtrub"})
+
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.Less(t, time.Since(start), time.Second)
+
+	// Запрос к бирже отменяется вместе с дедлайном клиента, а не по таймауту провайдера в 500 мс
+	assert.Eventually(t, func() bool {
+		return exchange.Cancelled("usdtrub") == 1
+	}, 250*time.Millisecond, 10*time.Millisecond)
+}
+
+func TestIntegration_GetRates_ClientCancel(t *testing.T) {
+	exchange := fakegarantex.New()
+	defer exchange.Close()
+	exchange.Enqueue("usdtrub", fakegarantex.Slow(2*time.Second, fakegarantex.Prices("100", "99")))
+
+	client := newIntegrationClient(t, exchange)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	_, err := client.GetRates(ctx, &proto.GetRatesRequest{Market: "usdtrub"})
+
+	assert.Equal(t, codes.Canceled, status.Code(err))
+	assert.Eventually(t, func() bool {
+		return exchange.Cancelled("usdtrub") == 1
+	}, 250*time.Millisecond, 10*time.Millisecond)
+}
+
+func TestIntegration_GetRates_FailoverExhausted(t *testing.T) {
+	primary := fakegarantex.New()
+	defer primary.Close()
+	primary.Enqueue("usdtrub", fakegarantex.Status(http.StatusBadGateway))
+	secondary := fakegarantex.New()
+	defer secondary.Close()
+	secondary.Enqueue("usdtrub", fakegarantex.EmptyBook())
+
+	failover, err := provider.NewFailover([]provider.RateProvider{
+		newTestGarantex(t, primary),
+		newTestGarantex(t, secondary),
+	}, nil)
+	require.NoError(t, err)
+
+	client := newRatesClient(t, failover)
+
+	_, err = client.GetRates(context.Background(), &proto.GetRatesRequest{Market: "usdtrub"})
+
+	// Ни один провайдер не вернул стакан
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 1, primary.Requests("usdtrub"))
+	assert.Equal(t, 1, secondary.Requests("usdtrub"))
+}
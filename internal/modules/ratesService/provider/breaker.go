@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"getUSDT/config"
+	"getUSDT/internal/models"
+	"sync"
+	"time"
+)
+
+const defaultOpenTimeout = 30 * time.Second
+
+// Состояния автоматического выключателя
+const (
+	BreakerClosed   = "closed"    // Запросы проходят
+	BreakerOpen     = "open"      // Запросы отклоняются без обращения к бирже
+	BreakerHalfOpen = "half-open" // Пропускается пробный запрос
+)
+
+// ErrCircuitOpen возвращается, пока выключатель провайдера разомкнут
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitBreaker провайдер, прекращающий обращения к бирже после серии ошибок.
+// После failureThreshold ошибок подряд выключатель размыкается на openTimeout,
+// затем пропускает один пробный запрос: успех замыкает его, ошибка снова размыкает.
+type CircuitBreaker struct {
+	RateProvider
+	failureThreshold int
+	openTimeout      time.Duration
+	now              func() time.Time
+
+	mu        sync.Mutex
+	state     string
+	failures  int
+	openedAt  time.Time
+	lastError error
+}
+
+// NewCircuitBreaker оборачивает провайдер автоматическим выключателем
+func NewCircuitBreaker(p RateProvider, cfg config.BreakerConfig) *CircuitBreaker {
+	openTimeout := cfg.OpenTimeout
+	if openTimeout <= 0 {
+		openTimeout = defaultOpenTimeout
+	}
+
+	return &CircuitBreaker{
+		RateProvider:     p,
+		failureThreshold: cfg.FailureThreshold,
+		openTimeout:      openTimeout,
+		now:              time.Now,
+		state:            BreakerClosed,
+	}
+}
+
+// FetchOrderBook запрашивает стакан, если выключатель это позволяет
+func (b *CircuitBreaker) FetchOrderBook(ctx context.Context, market string) (*models.OrderBook, error) {
+	if !b.allow() {
+		return nil, fmt.Errorf("%s: %w", b.Name(), ErrCircuitOpen)
+	}
+
+	book, err := b.RateProvider.FetchOrderBook(ctx, market)
+
+	// Отмена запроса клиентом не говорит о недоступности биржи
+	if err != nil && ctx.Err() != nil {
+		b.release()
+		return nil, err
+	}
+	b.record(err)
+	return book, err
+}
+
+// State возвращает текущее состояние выключателя
+func (b *CircuitBreaker) State() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerOpen && b.now().Sub(b.openedAt) >= b.openTimeout {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+// Healthy сообщает, стоит ли сейчас обращаться к провайдеру.
+// Пока выполняется пробный запрос, выключатель отклоняет остальные, поэтому провайдер считается недоступным.
+func (b *CircuitBreaker) Healthy() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.admits()
+}
+
+// Check возвращает ошибку, пока выключатель разомкнут
+func (b *CircuitBreaker) Check(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerOpen && b.now().Sub(b.openedAt) < b.openTimeout {
+		return fmt.Errorf("%w after %d failures: %v", ErrCircuitOpen, b.failures, b.lastError)
+	}
+	return nil
+}
+
+// allow решает, пропускать ли запрос, и переводит выключатель в полуоткрытое состояние
+func (b *CircuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.admits() {
+		return false
+	}
+	if b.state == BreakerOpen {
+		// Пропускаем один пробный запрос
+		b.state = BreakerHalfOpen
+	}
+	return true
+}
+
+// admits сообщает, будет ли пропущен следующий запрос. Вызывается под b.mu
+func (b *CircuitBreaker) admits() bool {
+	switch b.state {
+	case BreakerOpen:
+		return b.now().Sub(b.openedAt) >= b.openTimeout
+	case BreakerHalfOpen:
+		// Пробный запрос уже выполняется
+		return false
+	default:
+		return true
+	}
+}
+
+// release возвращает выключатель из полуоткрытого состояния, не засчитывая результат
+func (b *CircuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerHalfOpen {
+		b.state = BreakerOpen
+		b.openedAt = b.now().Add(-b.openTimeout)
+	}
+}
+
+// record учитывает результат запроса
+func (b *CircuitBreaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil {
+		b.state = BreakerClosed
+		b.failures = 0
+		b.lastError = nil
+		return
+	}
+
+	b.failures++
+	b.lastError = err
+	if b.state == BreakerHalfOpen || b.failures >= b.failureThreshold {
+		b.state = BreakerOpen
+		b.openedAt = b.now()
+	}
+}
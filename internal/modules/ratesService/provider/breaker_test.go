@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"getUSDT/config"
+	"getUSDT/internal/models"
+	"getUSDT/internal/modules/ratesService/provider/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker_OpensAfterFailures(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Биржа отвечает ошибкой дважды, третий запрос до нее не доходит
+	m := mocks.NewMockRateProvider(ctrl)
+	m.EXPECT().Name().Return("a").AnyTimes()
+	m.EXPECT().FetchOrderBook(gomock.Any(), "usdtrub").Return(nil, errors.New("timeout")).Times(2)
+
+	breaker := NewCircuitBreaker(m, config.BreakerConfig{FailureThreshold: 2, OpenTimeout: time.Minute})
+
+	for i := 0; i < 2; i++ {
+		_, err := breaker.FetchOrderBook(context.Background(), "usdtrub")
+		assert.Error(t, err)
+	}
+
+	_, err := breaker.FetchOrderBook(context.Background(), "usdtrub")
+
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, BreakerOpen, breaker.State())
+	assert.False(t, breaker.Healthy())
+	assert.Error(t, breaker.Check(context.Background()))
+}
+
+func TestCircuitBreaker_ClosesAfterSuccessfulTrial(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := mocks.NewMockRateProvider(ctrl)
+	m.EXPECT().Name().Return("a").AnyTimes()
+	gomock.InOrder(
+		m.EXPECT().FetchOrderBook(gomock.Any(), "usdtrub").Return(nil, errors.New("timeout")),
+		m.EXPECT().FetchOrderBook(gomock.Any(), "usdtrub").Return(&models.OrderBook{Source: "a"}, nil),
+	)
+
+	breaker := NewCircuitBreaker(m, config.BreakerConfig{FailureThreshold: 1, OpenTimeout: time.Minute})
+	now := time.Now()
+	breaker.now = func() time.Time { return now }
+
+	_, err := breaker.FetchOrderBook(context.Background(), "usdtrub")
+	assert.Error(t, err)
+	assert.Equal(t, BreakerOpen, breaker.State())
+
+	// По истечении времени размыкания пропускается пробный запрос
+	now = now.Add(time.Minute)
+	assert.Equal(t, BreakerHalfOpen, breaker.State())
+
+	book, err := breaker.FetchOrderBook(context.Background(), "usdtrub")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "a", book.Source)
+	assert.Equal(t, BreakerClosed, breaker.State())
+	assert.NoError(t, breaker.Check(context.Background()))
+}
+
+func TestCircuitBreaker_UnhealthyDuringTrial(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	started := make(chan struct{})
+	finish := make(chan struct{})
+	m := mocks.NewMockRateProvider(ctrl)
+	m.EXPECT().Name().Return("a").AnyTimes()
+	gomock.InOrder(
+		m.EXPECT().FetchOrderBook(gomock.Any(), "usdtrub").Return(nil, errors.New("timeout")),
+		m.EXPECT().FetchOrderBook(gomock.Any(), "usdtrub").DoAndReturn(func(context.Context, string) (*models.OrderBook, error) {
+			close(started)
+			<-finish
+			return &models.OrderBook{Source: "a"}, nil
+		}),
+	)
+
+	breaker := NewCircuitBreaker(m, config.BreakerConfig{FailureThreshold: 1, OpenTimeout: time.Minute})
+	now := time.Now().Add(-time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	_, err := breaker.FetchOrderBook(context.Background(), "usdtrub")
+	assert.Error(t, err)
+	now = now.Add(time.Minute)
+	assert.True(t, breaker.Healthy())
+
+	trial := make(chan error, 1)
+	go func() {
+		_, err := breaker.FetchOrderBook(context.Background(), "usdtrub")
+		trial <- err
+	}()
+	<-started
+
+	// Пока пробный запрос не завершен, остальные запросы отклоняются, и Healthy это отражает
+	assert.False(t, breaker.Healthy())
+	_, err = breaker.FetchOrderBook(context.Background(), "usdtrub")
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+
+	close(finish)
+	assert.NoError(t, <-trial)
+	assert.True(t, breaker.Healthy())
+}
@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"getUSDT/config"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Capture сохраненный сырой ответ биржи на запрос стакана
+type Capture struct {
+	Provider   string      `json:"provider"`
+	Market     string      `json:"market"`
+	URL        string      `json:"url"`
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       string      `json:"body"`
+	LatencyMS  int64       `json:"latency_ms"`
+	CapturedAt time.Time   `json:"captured_at"`
+}
+
+// capturedHeaders заголовки ответа, которые сохраняются вместе с ним.
+// Остальные, например Set-Cookie, могут содержать секреты и на диск не попадают.
+var capturedHeaders = []string{"Content-Type", "Date"}
+
+// defaultCaptureMaxFiles число хранимых ответов на рынок по умолчанию
+const defaultCaptureMaxFiles = 1000
+
+// CaptureRecorder сохраняет ответы биржи в каталог, по файлу на ответ.
+// Файлы раскладываются по подкаталогам рынков и упорядочены по времени получения.
+// После каждой записи старые ответы сверх ограничений по числу и возрасту удаляются.
+type CaptureRecorder struct {
+	dir      string
+	maxFiles int
+	maxAge   time.Duration
+	now      func() time.Time
+	mu       sync.Mutex
+	seq      int
+}
+
+// NewCaptureRecorder создает каталог для ответов и возвращает объект записи
+func NewCaptureRecorder(cfg config.CaptureConfig) (*CaptureRecorder, error) {
+	if cfg.Dir == "" {
+		return nil, fmt.Errorf("capture dir is not set")
+	}
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create capture dir: %w", err)
+	}
+	maxFiles := cfg.MaxFiles
+	if maxFiles <= 0 {
+		maxFiles = defaultCaptureMaxFiles
+	}
+	return &CaptureRecorder{dir: cfg.Dir, maxFiles: maxFiles, maxAge: cfg.MaxAge, now: time.Now}, nil
+}
+
+// Record сохраняет ответ биржи. Из заголовков сохраняются только capturedHeaders
+func (r *CaptureRecorder) Record(c *Capture) error {
+	captured := *c
+	captured.Header = filterHeader(c.Header)
+	data, err := json.MarshalIndent(&captured, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode capture: %w", err)
+	}
+
+	dir := filepath.Join(r.dir, c.Market)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create capture dir: %w", err)
+	}
+
+	// Порядковый номер различает ответы, полученные в одну и ту же наносекунду
+	r.mu.Lock()
+	r.seq++
+	name := fmt.Sprintf("%020d-%06d.json", c.CapturedAt.UnixNano(), r.seq%1000000)
+	r.mu.Unlock()
+
+	if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write capture: %w", err)
+	}
+	return r.prune(dir)
+}
+
+// filterHeader возвращает копию заголовков, содержащую только capturedHeaders
+func filterHeader(header http.Header) http.Header {
+	filtered := make(http.Header, len(capturedHeaders))
+	for _, name := range capturedHeaders {
+		if values := header.Values(name); len(values) > 0 {
+			filtered[name] = append([]string(nil), values...)
+		}
+	}
+	return filtered
+}
+
+// prune удаляет ответы рынка старше maxAge и самые старые ответы сверх maxFiles.
+// Время получения берется из имени файла.
+func (r *CaptureRecorder) prune(dir string) error {
+	names, err := captureNames(dir)
+	if err != nil {
+		return err
+	}
+
+	// Имена отсортированы по времени получения, поэтому удаляем с начала списка
+	remove := 0
+	if len(names) > r.maxFiles {
+		remove = len(names) - r.maxFiles
+	}
+	if r.maxAge > 0 {
+		cutoff := r.now().Add(-r.maxAge).UnixNano()
+		for remove < len(names) {
+			capturedAt, ok := captureTime(names[remove])
+			if !ok || capturedAt >= cutoff {
+				break
+			}
+			remove++
+		}
+	}
+
+	for _, name := range names[:remove] {
+		if err := os.Remove(filepath.Join(dir, name)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove capture %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// captureNames возвращает имена файлов ответов в порядке их получения
+func captureNames(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read captures: %w", err)
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".json") {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// captureTime извлекает время получения ответа в наносекундах UNIX из имени файла
+func captureTime(name string) (int64, bool) {
+	prefix, _, ok := strings.Cut(name, "-")
+	if !ok {
+		return 0, false
+	}
+	nanos, err := strconv.ParseInt(prefix, 10, 64)
+	return nanos, err == nil
+}
+
+// LoadCaptures загружает сохраненные ответы рынка в порядке их получения
+func LoadCaptures(dir, market string) ([]Capture, error) {
+	names, err := captureNames(filepath.Join(dir, market))
+	if err != nil {
+		return nil, err
+	}
+
+	captures := make([]Capture, 0, len(names))
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(dir, market, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read capture %s: %w", name, err)
+		}
+		var c Capture
+		if err := json.Unmarshal(data, &c); err != nil {
+			return nil, fmt.Errorf("failed to decode capture %s: %w", name, err)
+		}
+		captures = append(captures, c)
+	}
+	return captures, nil
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"getUSDT/config"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaptureRecorder_MaxFiles(t *testing.T) {
+	dir := t.TempDir()
+	recorder, err := NewCaptureRecorder(config.CaptureConfig{Dir: dir, MaxFiles: 3})
+	require.NoError(t, err)
+
+	start := time.Unix(1700000000, 0)
+	for i := 0; i < 5; i++ {
+		require.NoError(t, recorder.Record(&Capture{
+			Market:     "usdtrub",
+			Body:       string(rune('a' + i)),
+			CapturedAt: start.Add(time.Duration(i) * time.Second),
+		}))
+	}
+
+	// Остаются только три последних ответа
+	captures, err := LoadCaptures(dir, "usdtrub")
+	require.NoError(t, err)
+	require.Len(t, captures, 3)
+	assert.Equal(t, "c", captures[0].Body)
+	assert.Equal(t, "e", captures[2].Body)
+}
+
+func TestCaptureRecorder_MaxAge(t *testing.T) {
+	dir := t.TempDir()
+	recorder, err := NewCaptureRecorder(config.CaptureConfig{Dir: dir, MaxAge: time.Hour})
+	require.NoError(t, err)
+
+	now := time.Unix(1700000000, 0)
+	recorder.now = func() time.Time { return now }
+
+	for _, age := range []time.Duration{3 * time.Hour, 2 * time.Hour, 30 * time.Minute, 0} {
+		require.NoError(t, recorder.Record(&Capture{Market: "usdtrub", CapturedAt: now.Add(-age)}))
+	}
+
+	// Ответы старше часа удалены
+	captures, err := LoadCaptures(dir, "usdtrub")
+	require.NoError(t, err)
+	require.Len(t, captures, 2)
+	assert.True(t, captures[0].CapturedAt.Equal(now.Add(-30*time.Minute)))
+}
+
+func TestCaptureRecorder_StoresAllowedHeaders(t *testing.T) {
+	dir := t.TempDir()
+	recorder, err := NewCaptureRecorder(config.CaptureConfig{Dir: dir})
+	require.NoError(t, err)
+
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set("Date", "Tue, 14 Nov 2023 22:13:20 GMT")
+	header.Set("Set-Cookie", "session=secret")
+	header.Set("X-Request-Id", "42")
+	require.NoError(t, recorder.Record(&Capture{Market: "usdtrub", Header: header, CapturedAt: time.Unix(1700000000, 0)}))
+
+	// Сохраняются только разрешенные заголовки, исходные заголовки не меняются
+	captures, err := LoadCaptures(dir, "usdtrub")
+	require.NoError(t, err)
+	require.Len(t, captures, 1)
+	assert.Equal(t, http.Header{
+		"Content-Type": {"application/json"},
+		"Date":         {"Tue, 14 Nov 2023 22:13:20 GMT"},
+	}, captures[0].Header)
+	assert.Equal(t, "session=secret", header.Get("Set-Cookie"))
+}
@@ -0,0 +1,276 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"getUSDT/config"
+	"getUSDT/internal/models"
+	"sort"
+	"sync"
+
+	"github.com/shopspring/decimal"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// CompositeName имя составного провайдера в конфигурации
+const CompositeName = "composite"
+
+// Composite провайдер, рассчитывающий референсный курс по нескольким биржам.
+// Котировки, отклоняющиеся от медианы больше допустимого, отбрасываются,
+// а курс рассчитывается как взвешенная медиана середин оставшихся котировок
+// плюс-минус взвешенная медиана их полуспредов.
+type Composite struct {
+	members      []RateProvider
+	weights      map[string]decimal.Decimal
+	maxDeviation decimal.Decimal
+	minSources   int
+}
+
+// NewComposite создает составной провайдер из указанных провайдеров
+func NewComposite(members []RateProvider, cfg config.CompositeConfig) (*Composite, error) {
+	if len(members) == 0 {
+		return nil, fmt.Errorf("composite provider requires at least one member")
+	}
+
+	minSources := cfg.MinSources
+	if minSources <= 0 {
+		minSources = 1
+	}
+
+	// Вес по умолчанию равен 1
+	memberWeights := make(map[string]decimal.Decimal, len(members))
+	for _, m := range members {
+		weight := decimal.NewFromInt(1)
+		if w, ok := cfg.Weights[m.Name()]; ok && w > 0 {
+			weight = decimal.NewFromFloat(w)
+		}
+		memberWeights[m.Name()] = weight
+	}
+
+	return &Composite{
+		members:      members,
+		weights:      memberWeights,
+		maxDeviation: decimal.NewFromFloat(cfg.MaxDeviation),
+		minSources:   minSources,
+	}, nil
+}
+
+// Name возвращает имя провайдера
+func (c *Composite) Name() string {
+	return CompositeName
+}
+
+// Markets возвращает рынки, поддерживаемые хотя бы одним из провайдеров
+func (c *Composite) Markets() []string {
+	seen := make(map[string]struct{})
+	var markets []string
+	for _, m := range c.members {
+		for _, market := range m.Markets() {
+			if _, ok := seen[market]; !ok {
+				seen[market] = struct{}{}
+				markets = append(markets, market)
+			}
+		}
+	}
+	return markets
+}
+
+// memberQuote вершина стакана одного провайдера
+type memberQuote struct {
+	source    string
+	weight    decimal.Decimal
+	ask       decimal.Decimal
+	bid       decimal.Decimal
+	mid       decimal.Decimal
+	half      decimal.Decimal // Половина спреда
+	askVolume decimal.Decimal
+	bidVolume decimal.Decimal
+}
+
+// FetchOrderBook параллельно опрашивает провайдеров и возвращает стакан из одного уровня
+// с референсными ценами ask и bid и списком бирж, участвовавших в расчете
+func (c *Composite) FetchOrderBook(ctx context.Context, market string) (*models.OrderBook, error) {
+	// Создаем трассировщик для отслеживания выполнения этой операции
+	tracer := otel.Tracer("getUSDT.provider")
+	ctx, span := tracer.Start(ctx, "Composite.FetchOrderBook")
+	defer span.End()
+
+	quotes, err := c.collect(ctx, span, market)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "No quotes available")
+		return nil, err
+	}
+
+	// Отбрасываем котировки, отклоняющиеся от медианы больше допустимого
+	reference := weightedMedian(quotes, func(q memberQuote) decimal.Decimal { return q.mid })
+	accepted := make([]memberQuote, 0, len(quotes))
+	for _, q := range quotes {
+		deviation := q.mid.Sub(reference).Abs().Div(reference)
+		if c.maxDeviation.IsPositive() && deviation.GreaterThan(c.maxDeviation) {
+			span.AddEvent("Quote rejected as outlier", trace.WithAttributes(
+				attribute.String("provider", q.source),
+				attribute.String("deviation", deviation.String()),
+			))
+			continue
+		}
+		accepted = append(accepted, q)
+	}
+
+	if len(accepted) < c.minSources {
+		err := fmt.Errorf("composite provider: %d sources accepted, at least %d required", len(accepted), c.minSources)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Not enough sources")
+		return nil, err
+	}
+
+	// Обе стороны строятся от одной середины: независимые медианы ask и bid
+	// берутся у разных бирж и могут дать несогласованный стакан
+	mid := weightedMedian(accepted, func(q memberQuote) decimal.Decimal { return q.mid })
+	half := weightedMedian(accepted, func(q memberQuote) decimal.Decimal { return q.half })
+
+	book := &models.OrderBook{
+		Source: CompositeName,
+		Market: market,
+		Asks: []models.OrderBookLevel{{
+			Price:  mid.Add(half).String(),
+			Volume: sumOf(accepted, func(q memberQuote) decimal.Decimal { return q.askVolume }).String(),
+		}},
+		Bids: []models.OrderBookLevel{{
+			Price:  mid.Sub(half).String(),
+			Volume: sumOf(accepted, func(q memberQuote) decimal.Decimal { return q.bidVolume }).String(),
+		}},
+	}
+	for _, q := range accepted {
+		book.Sources = append(book.Sources, q.source)
+	}
+
+	span.SetAttributes(attribute.StringSlice("composite.sources", book.Sources))
+	span.SetStatus(codes.Ok, "Reference rate computed successfully")
+	return book, nil
+}
+
+// collect параллельно получает вершины стаканов всех провайдеров, поддерживающих рынок
+func (c *Composite) collect(ctx context.Context, span trace.Span, market string) ([]memberQuote, error) {
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		quotes []memberQuote
+		errs   []error
+	)
+
+	for _, m := range c.members {
+		if !Supports(m, market) {
+			continue
+		}
+
+		wg.Add(1)
+		go func(m RateProvider) {
+			defer wg.Done()
+
+			q, err := c.fetchQuote(ctx, m, market)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", m.Name(), err))
+				span.AddEvent("Provider failed", trace.WithAttributes(
+					attribute.String("provider", m.Name()),
+					attribute.String("error", err.Error()),
+				))
+				return
+			}
+			quotes = append(quotes, q)
+		}(m)
+	}
+	wg.Wait()
+
+	if len(quotes) == 0 {
+		if len(errs) == 0 {
+			return nil, fmt.Errorf("composite provider: no provider supports market %s", market)
+		}
+		return nil, fmt.Errorf("composite provider: all providers failed: %w", errors.Join(errs...))
+	}
+
+	// Упорядочиваем по имени, чтобы результат не зависел от порядка ответов
+	sort.Slice(quotes, func(i, j int) bool { return quotes[i].source < quotes[j].source })
+	return quotes, nil
+}
+
+// fetchQuote получает стакан провайдера и извлекает из него вершину
+func (c *Composite) fetchQuote(ctx context.Context, m RateProvider, market string) (memberQuote, error) {
+	book, err := m.FetchOrderBook(ctx, market)
+	if err != nil {
+		return memberQuote{}, err
+	}
+	if len(book.Asks) == 0 || len(book.Bids) == 0 {
+		return memberQuote{}, fmt.Errorf("empty order book")
+	}
+
+	ask, err := decimal.NewFromString(book.Asks[0].Price)
+	if err != nil {
+		return memberQuote{}, fmt.Errorf("failed to parse ask price: %w", err)
+	}
+	bid, err := decimal.NewFromString(book.Bids[0].Price)
+	if err != nil {
+		return memberQuote{}, fmt.Errorf("failed to parse bid price: %w", err)
+	}
+	if !ask.IsPositive() || !bid.IsPositive() {
+		return memberQuote{}, fmt.Errorf("non-positive prices")
+	}
+	// Пересеченный стакан биржи дал бы отрицательный спред
+	if !bid.LessThan(ask) {
+		return memberQuote{}, fmt.Errorf("crossed order book")
+	}
+
+	// Объем не обязателен для расчета курса
+	askVolume, _ := decimal.NewFromString(book.Asks[0].Volume)
+	bidVolume, _ := decimal.NewFromString(book.Bids[0].Volume)
+
+	return memberQuote{
+		source:    m.Name(),
+		weight:    c.weights[m.Name()],
+		ask:       ask,
+		bid:       bid,
+		mid:       ask.Add(bid).Div(decimal.NewFromInt(2)),
+		half:      ask.Sub(bid).Div(decimal.NewFromInt(2)),
+		askVolume: askVolume,
+		bidVolume: bidVolume,
+	}, nil
+}
+
+// weightedMedian возвращает взвешенную медиану значений котировок.
+// Если накопленный вес ровно делит котировки пополам, берется среднее двух соседних значений.
+func weightedMedian(quotes []memberQuote, value func(memberQuote) decimal.Decimal) decimal.Decimal {
+	sorted := make([]memberQuote, len(quotes))
+	copy(sorted, quotes)
+	sort.SliceStable(sorted, func(i, j int) bool { return value(sorted[i]).LessThan(value(sorted[j])) })
+
+	total := sumOf(sorted, func(q memberQuote) decimal.Decimal { return q.weight })
+	half := total.Div(decimal.NewFromInt(2))
+
+	cumulative := decimal.Zero
+	for i, q := range sorted {
+		cumulative = cumulative.Add(q.weight)
+		if cumulative.Equal(half) && i+1 < len(sorted) {
+			return value(q).Add(value(sorted[i+1])).Div(decimal.NewFromInt(2))
+		}
+		if cumulative.GreaterThan(half) {
+			return value(q)
+		}
+	}
+	return value(sorted[len(sorted)-1])
+}
+
+// sumOf суммирует значения котировок
+func sumOf(quotes []memberQuote, value func(memberQuote) decimal.Decimal) decimal.Decimal {
+	sum := decimal.Zero
+	for _, q := range quotes {
+		sum = sum.Add(value(q))
+	}
+	return sum
+}
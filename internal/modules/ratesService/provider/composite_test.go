@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"getUSDT/config"
+	"getUSDT/internal/models"
+	"getUSDT/internal/modules/ratesService/provider/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// newMember создает мок провайдера, возвращающего стакан с одним уровнем
+func newMember(ctrl *gomock.Controller, name, ask, bid string, err error) *mocks.MockRateProvider {
+	m := mocks.NewMockRateProvider(ctrl)
+	m.EXPECT().Name().Return(name).AnyTimes()
+	m.EXPECT().Markets().Return([]string{"usdtrub"}).AnyTimes()
+	if err != nil {
+		m.EXPECT().FetchOrderBook(gomock.Any(), "usdtrub").Return(nil, err).Times(1)
+		return m
+	}
+	m.EXPECT().FetchOrderBook(gomock.Any(), "usdtrub").Return(&models.OrderBook{
+		Source: name,
+		Market: "usdtrub",
+		Asks:   []models.OrderBookLevel{{Price: ask, Volume: "10"}},
+		Bids:   []models.OrderBookLevel{{Price: bid, Volume: "5"}},
+	}, nil).Times(1)
+	return m
+}
+
+func TestComposite_MedianWithOutlierRejection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	members := []RateProvider{
+		newMember(ctrl, "a", "100", "99", nil),
+		newMember(ctrl, "b", "101", "100", nil),
+		newMember(ctrl, "c", "102", "101", nil),
+		newMember(ctrl, "d", "150", "149", nil), // Выброс
+		newMember(ctrl, "e", "", "", errors.New("unavailable")),
+	}
+
+	composite, err := NewComposite(members, config.CompositeConfig{MaxDeviation: 0.05, MinSources: 2})
+	assert.NoError(t, err)
+
+	book, err := composite.FetchOrderBook(context.Background(), "usdtrub")
+
+	// Выброс и недоступная биржа не участвуют в расчете
+	assert.NoError(t, err)
+	assert.Equal(t, CompositeName, book.Source)
+	assert.Equal(t, []string{"a", "b", "c"}, book.Sources)
+	assert.Equal(t, "101", book.Asks[0].Price)
+	assert.Equal(t, "100", book.Bids[0].Price)
+	assert.Equal(t, "30", book.Asks[0].Volume)
+}
+
+func TestComposite_WeightedMedian(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	members := []RateProvider{
+		newMember(ctrl, "a", "100", "99", nil),
+		newMember(ctrl, "b", "101", "100", nil),
+		newMember(ctrl, "c", "102", "101", nil),
+	}
+
+	// Вес биржи c больше суммы остальных, поэтому медиана смещается к ее цене
+	composite, err := NewComposite(members, config.CompositeConfig{Weights: map[string]float64{"c": 3}})
+	assert.NoError(t, err)
+
+	book, err := composite.FetchOrderBook(context.Background(), "usdtrub")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "102", book.Asks[0].Price)
+	assert.Equal(t, "101", book.Bids[0].Price)
+}
+
+func TestComposite_ConsistentSides(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Независимые медианы дали бы ask 100.2 и bid 100.3, то есть пересеченный стакан
+	members := []RateProvider{
+		newMember(ctrl, "a", "99", "101", nil), // Пересеченный стакан биржи
+		newMember(ctrl, "b", "100.2", "99.8", nil),
+		newMember(ctrl, "c", "100.4", "100.3", nil),
+	}
+
+	composite, err := NewComposite(members, config.CompositeConfig{})
+	assert.NoError(t, err)
+
+	book, err := composite.FetchOrderBook(context.Background(), "usdtrub")
+
+	// Обе стороны строятся от медианы середин 100.175 и медианного полуспреда 0.125
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b", "c"}, book.Sources)
+	assert.Equal(t, "100.3", book.Asks[0].Price)
+	assert.Equal(t, "100.05", book.Bids[0].Price)
+}
+
+func TestComposite_NotEnoughSources(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	members := []RateProvider{
+		newMember(ctrl, "a", "100", "99", nil),
+		newMember(ctrl, "b", "", "", errors.New("unavailable")),
+	}
+
+	composite, err := NewComposite(members, config.CompositeConfig{MinSources: 2})
+	assert.NoError(t, err)
+
+	_, err = composite.FetchOrderBook(context.Background(), "usdtrub")
+
+	assert.Error(t, err)
+}
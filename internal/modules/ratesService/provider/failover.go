@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"getUSDT/internal/models"
+	"getUSDT/internal/monitoring"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// FailoverName имя провайдера с переключением в конфигурации
+const FailoverName = "failover"
+
+// noneProvider значение метки "to", когда переключаться больше некуда
+const noneProvider = "none"
+
+// HealthReporter реализуется провайдерами, которые знают о своей доступности
+type HealthReporter interface {
+	// Healthy сообщает, стоит ли сейчас обращаться к провайдеру
+	Healthy() bool
+}
+
+// Failover провайдер, опрашивающий провайдеров по порядку до первого успешного ответа
+type Failover struct {
+	providers []RateProvider
+	metrics   *monitoring.Metrics
+}
+
+// NewFailover создает провайдер с переключением между провайдерами в указанном порядке.
+// metrics может быть nil.
+func NewFailover(providers []RateProvider, metrics *monitoring.Metrics) (*Failover, error) {
+	if len(providers) == 0 {
+		return nil, fmt.Errorf("failover provider requires at least one provider")
+	}
+	return &Failover{
+		providers: providers,
+		metrics:   metrics,
+	}, nil
+}
+
+// Name возвращает имя провайдера
+func (f *Failover) Name() string {
+	return FailoverName
+}
+
+// Markets возвращает рынки, поддерживаемые хотя бы одним из провайдеров
+func (f *Failover) Markets() []string {
+	seen := make(map[string]struct{})
+	var markets []string
+	for _, p := range f.providers {
+		for _, market := range p.Markets() {
+			if _, ok := seen[market]; !ok {
+				seen[market] = struct{}{}
+				markets = append(markets, market)
+			}
+		}
+	}
+	return markets
+}
+
+// FetchOrderBook возвращает стакан первого доступного провайдера.
+// Ошибка или пустой стакан приводят к переключению на следующий провайдер.
+// Имя провайдера, вернувшего стакан, сохраняется в OrderBook.Source.
+func (f *Failover) FetchOrderBook(ctx context.Context, market string) (*models.OrderBook, error) {
+	// Создаем трассировщик для отслеживания выполнения этой операции
+	tracer := otel.Tracer("getUSDT.provider")
+	ctx, span := tracer.Start(ctx, "Failover.FetchOrderBook")
+	defer span.End()
+
+	var (
+		errs   []error
+		failed string
+	)
+	for _, p := range f.providers {
+		if !Supports(p, market) {
+			continue
+		}
+		if reporter, ok := p.(HealthReporter); ok && !reporter.Healthy() {
+			span.AddEvent("Provider skipped as unhealthy", trace.WithAttributes(
+				attribute.String("provider", p.Name()),
+			))
+			errs = append(errs, fmt.Errorf("%s: unhealthy", p.Name()))
+			// Пропуск недоступного провайдера тоже переключение, иначе счетчик молчит во время сбоя
+			failed = p.Name()
+			continue
+		}
+
+		if failed != "" {
+			f.recordFailover(span, failed, p.Name())
+		}
+
+		book, err := p.FetchOrderBook(ctx, market)
+		if err == nil && (len(book.Asks) == 0 || len(book.Bids) == 0) {
+			err = fmt.Errorf("empty order book")
+		}
+		if err != nil {
+			span.AddEvent("Provider failed", trace.WithAttributes(
+				attribute.String("provider", p.Name()),
+				attribute.String("error", err.Error()),
+			))
+			errs = append(errs, fmt.Errorf("%s: %w", p.Name(), err))
+			failed = p.Name()
+
+			// Отмененный запрос нет смысла отправлять следующему провайдеру
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+
+		span.SetAttributes(attribute.String("failover.source", book.Source))
+		span.SetStatus(codes.Ok, "Order book fetched successfully")
+		return book, nil
+	}
+
+	if failed != "" {
+		f.recordFailover(span, failed, noneProvider)
+	}
+
+	err := fmt.Errorf("failover provider: no provider returned order book: %w", errors.Join(errs...))
+	span.RecordError(err)
+	span.SetStatus(codes.Error, "All providers failed")
+	return nil, err
+}
+
+// recordFailover фиксирует переключение провайдера в метриках и трассировке
+func (f *Failover) recordFailover(span trace.Span, from, to string) {
+	span.AddEvent("Provider failover", trace.WithAttributes(
+		attribute.String("failover.from", from),
+		attribute.String("failover.to", to),
+	))
+	if f.metrics != nil {
+		f.metrics.ProviderFailovers.WithLabelValues(from, to).Inc()
+	}
+}
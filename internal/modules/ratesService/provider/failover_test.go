@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"getUSDT/internal/models"
+	"getUSDT/internal/modules/ratesService/provider/mocks"
+	"getUSDT/internal/monitoring"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+// unhealthyProvider провайдер, сообщающий о своей недоступности
+type unhealthyProvider struct {
+	*mocks.MockRateProvider
+}
+
+func (unhealthyProvider) Healthy() bool { return false }
+
+func TestFailover_FallsBackToNextProvider(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Первый провайдер отвечает ошибкой, второй — пустым стаканом, третий — корректно
+	members := []RateProvider{
+		newMember(ctrl, "a", "", "", errors.New("API returned non-200 status code: 502")),
+		emptyMember(ctrl, "b"),
+		newMember(ctrl, "c", "100", "99", nil),
+	}
+
+	metrics := monitoring.NewMetricsWithRegistry(prometheus.NewRegistry())
+	failover, err := NewFailover(members, metrics)
+	assert.NoError(t, err)
+
+	book, err := failover.FetchOrderBook(context.Background(), "usdtrub")
+
+	// Стакан возвращает третий провайдер, и это отражено в источнике
+	assert.NoError(t, err)
+	assert.Equal(t, "c", book.Source)
+
+	// Каждое переключение учтено в метриках
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.ProviderFailovers.WithLabelValues("a", "b")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.ProviderFailovers.WithLabelValues("b", "c")))
+	assert.Equal(t, 2, testutil.CollectAndCount(metrics.ProviderFailovers))
+}
+
+func TestFailover_SkipsUnhealthyProvider(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Недоступный провайдер не опрашивается
+	down := mocks.NewMockRateProvider(ctrl)
+	down.EXPECT().Name().Return("down").AnyTimes()
+	down.EXPECT().Markets().Return([]string{"usdtrub"}).AnyTimes()
+
+	members := []RateProvider{
+		unhealthyProvider{down},
+		newMember(ctrl, "b", "100", "99", nil),
+	}
+
+	metrics := monitoring.NewMetricsWithRegistry(prometheus.NewRegistry())
+	failover, err := NewFailover(members, metrics)
+	assert.NoError(t, err)
+
+	book, err := failover.FetchOrderBook(context.Background(), "usdtrub")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "b", book.Source)
+
+	// Переключение с недоступного провайдера учитывается так же, как после ошибки
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.ProviderFailovers.WithLabelValues("down", "b")))
+}
+
+func TestFailover_AllProvidersFailed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	members := []RateProvider{
+		newMember(ctrl, "a", "", "", errors.New("timeout")),
+		newMember(ctrl, "b", "", "", errors.New("timeout")),
+	}
+
+	failover, err := NewFailover(members, nil)
+	assert.NoError(t, err)
+
+	_, err = failover.FetchOrderBook(context.Background(), "usdtrub")
+
+	assert.Error(t, err)
+}
+
+// emptyMember создает мок провайдера, возвращающего пустой стакан
+func emptyMember(ctrl *gomock.Controller, name string) *mocks.MockRateProvider {
+	m := mocks.NewMockRateProvider(ctrl)
+	m.EXPECT().Name().Return(name).AnyTimes()
+	m.EXPECT().Markets().Return([]string{"usdtrub"}).AnyTimes()
+	m.EXPECT().FetchOrderBook(gomock.Any(), "usdtrub").Return(&models.OrderBook{Source: name}, nil).Times(1)
+	return m
+}
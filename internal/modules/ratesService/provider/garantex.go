@@ -0,0 +1,227 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"getUSDT/config"
+	"getUSDT/internal/models"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const (
+	// GarantexName имя провайдера Garantex в конфигурации
+	GarantexName = "garantex"
+
+	garantexDefaultURL     = "https://garantex.org/api/v2"
+	garantexDefaultTimeout = 10 * time.Second
+	defaultUserAgent       = "getUSDT"
+)
+
+// Garantex провайдер курсов биржи Garantex
+type Garantex struct {
+	baseURL     string
+	markets     []string
+	timeout     time.Duration
+	userAgent   string
+	maxBodySize int64
+	client      *http.Client
+	recorder    *CaptureRecorder
+}
+
+// NewGarantex создает провайдер Garantex по конфигурации
+func NewGarantex(cfg config.ProviderConfig) (RateProvider, error) {
+	baseURL := cfg.URL
+	if baseURL == "" {
+		baseURL = garantexDefaultURL
+	}
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = garantexDefaultTimeout
+	}
+	markets := cfg.Markets
+	if len(markets) == 0 {
+		markets = []string{"usdtrub"}
+	}
+	userAgent := cfg.HTTP.UserAgent
+	if userAgent == "" {
+		userAgent = defaultUserAgent
+	}
+	client, err := newHTTPClient(cfg.HTTP)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", GarantexName, err)
+	}
+	var recorder *CaptureRecorder
+	if cfg.Capture.Enabled {
+		if recorder, err = NewCaptureRecorder(cfg.Capture); err != nil {
+			return nil, fmt.Errorf("%s: %w", GarantexName, err)
+		}
+	}
+
+	return &Garantex{
+		baseURL:     strings.TrimRight(baseURL, "/"),
+		markets:     markets,
+		timeout:     timeout,
+		userAgent:   userAgent,
+		maxBodySize: maxBodySize(cfg.HTTP),
+		client:      client,
+		recorder:    recorder,
+	}, nil
+}
+
+// Структуры для парсинга ответа от Garantex API
+type AskBid struct {
+	Price  string `json:"price"`  // Цена на покупку/продажу
+	Volume string `json:"volume"` // Объем
+	Amount string `json:"amount"` // Сумма
+	Factor string `json:"factor"` // Коэффициент
+	Type   string `json:"type"`   // Тип
+}
+
+type ApiResponse struct {
+	Timestamp int64    `json:"timestamp"` // Время стакана в UNIX формате
+	Asks      []AskBid `json:"asks"`      // Список заявок на покупку
+	Bids      []AskBid `json:"bids"`      // Список заявок на продажу
+}
+
+// Name возвращает имя провайдера
+func (g *Garantex) Name() string {
+	return GarantexName
+}
+
+// Markets возвращает список рынков, поддерживаемых провайдером
+func (g *Garantex) Markets() []string {
+	return g.markets
+}
+
+// FetchOrderBook получает стакан с биржи Garantex с трассировкой.
+// Запрос привязан к контексту вызова: отмена или дедлайн gRPC запроса прерывают обращение к бирже,
+// а таймаут провайдера лишь ограничивает его сверху.
+func (g *Garantex) FetchOrderBook(ctx context.Context, market string) (*models.OrderBook, error) {
+	// Создаем трассировщик для отслеживания выполнения этой операции
+	tracer := otel.Tracer("getUSDT.provider")
+	ctx, span := tracer.Start(ctx, "Garantex.FetchOrderBook")
+	defer span.End()
+
+	// Ограничиваем запрос таймаутом провайдера, если дедлайн вызова не наступит раньше
+	ctx, cancel := context.WithTimeout(ctx, g.timeout)
+	defer cancel()
+	if deadline, ok := ctx.Deadline(); ok {
+		span.SetAttributes(attribute.Int64("http.timeout_ms", time.Until(deadline).Milliseconds()))
+	}
+
+	// URL для запроса к API
+	url := fmt.Sprintf("%s/depth?market=%s", g.baseURL, market)
+	span.SetAttributes(
+		attribute.String("http.method", "GET"), // Метод HTTP запроса
+		attribute.String("http.url", url),      // URL запроса
+	)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to build HTTP request")
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("User-Agent", g.userAgent)
+	req.Header.Set("Accept", "application/json")
+	// Передаем контекст трассировки бирже через заголовки запроса
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	start := time.Now() // Засекаем время начала запроса
+
+	// Отправляем запрос и логируем события
+	span.AddEvent("Sending HTTP request")
+	resp, err := g.client.Do(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to fetch rate from API")
+		return nil, fmt.Errorf("failed to fetch rate from API: %w", err)
+	}
+	span.AddEvent("HTTP response received") // Ответ получен
+	defer resp.Body.Close()
+
+	// Читаем тело целиком, чтобы его можно было сохранить для последующего воспроизведения
+	body, err := readBody(resp.Body, g.maxBodySize)
+	duration := time.Since(start) // Время ответа
+	span.SetAttributes(attribute.Float64("http.duration_ms", float64(duration.Milliseconds())))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to read API response")
+		return nil, fmt.Errorf("failed to read API response: %w", err)
+	}
+
+	if g.recorder != nil {
+		err := g.recorder.Record(&Capture{
+			Provider:   GarantexName,
+			Market:     market,
+			URL:        url,
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header,
+			Body:       string(body),
+			LatencyMS:  duration.Milliseconds(),
+			CapturedAt: start.UTC(),
+		})
+		// Ошибка записи не должна мешать получению курса
+		if err != nil {
+			span.RecordError(err)
+			span.AddEvent("Failed to capture API response")
+		}
+	}
+
+	book, err := parseGarantexDepth(market, resp.StatusCode, body)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Invalid API response")
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "Order book fetched successfully")
+	return book, nil
+}
+
+// parseGarantexDepth разбирает ответ Garantex на запрос стакана.
+// Используется как для ответов биржи, так и для воспроизведения сохраненных ответов.
+func parseGarantexDepth(market string, statusCode int, body []byte) (*models.OrderBook, error) {
+	// Проверяем статус ответа
+	if statusCode != http.StatusOK {
+		return nil, &StatusError{StatusCode: statusCode}
+	}
+
+	// Декодируем JSON ответ от API в структуру
+	var apiResponse ApiResponse
+	if err := json.Unmarshal(body, &apiResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode API response: %w", err)
+	}
+
+	book := &models.OrderBook{
+		Source: GarantexName,
+		Market: market,
+		Asks:   toLevels(apiResponse.Asks),
+		Bids:   toLevels(apiResponse.Bids),
+	}
+	if apiResponse.Timestamp > 0 {
+		book.Timestamp = time.Unix(apiResponse.Timestamp, 0).UTC()
+	}
+	return book, nil
+}
+
+// toLevels преобразует заявки Garantex в уровни стакана
+func toLevels(entries []AskBid) []models.OrderBookLevel {
+	levels := make([]models.OrderBookLevel, 0, len(entries))
+	for _, e := range entries {
+		levels = append(levels, models.OrderBookLevel{
+			Price:  e.Price,
+			Volume: e.Volume,
+			Amount: e.Amount,
+		})
+	}
+	return levels
+}
@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"getUSDT/config"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestGarantex_InjectsTraceContext(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		_, _ = w.Write([]byte(`{"asks":[{"price":"100","volume":"1"}],"bids":[{"price":"99","volume":"1"}]}`))
+	}))
+	defer server.Close()
+
+	g, err := NewGarantex(config.ProviderConfig{URL: server.URL})
+	assert.NoError(t, err)
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	book, err := g.FetchOrderBook(ctx, "usdtrub")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "100", book.Asks[0].Price)
+	assert.Contains(t, traceparent, traceID.String())
+}
+
+func TestGarantex_RespectsContextDeadline(t *testing.T) {
+	// Биржа отвечает дольше, чем позволяет дедлайн вызова
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	g, err := NewGarantex(config.ProviderConfig{URL: server.URL, Timeout: time.Minute})
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = g.FetchOrderBook(ctx, "usdtrub")
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestGarantex_UsesConfiguredClient(t *testing.T) {
+	// Прокси принимает запрос к бирже вместо нее
+	var userAgent, requestURL string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		requestURL = r.URL.String()
+		_, _ = w.Write([]byte(`{"asks":[{"price":"100","volume":"1"}],"bids":[{"price":"99","volume":"1"}]}`))
+	}))
+	defer proxy.Close()
+
+	g, err := NewGarantex(config.ProviderConfig{
+		URL: "http://exchange.invalid/api/v2/",
+		HTTP: config.HTTPConfig{
+			UserAgent: "getUSDT-test",
+			Proxy:     proxy.URL,
+		},
+	})
+	assert.NoError(t, err)
+
+	_, err = g.FetchOrderBook(context.Background(), "usdtrub")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "getUSDT-test", userAgent)
+	assert.Equal(t, "http://exchange.invalid/api/v2/depth?market=usdtrub", requestURL)
+}
+
+func TestGarantex_LimitsResponseBody(t *testing.T) {
+	body := `{"asks":[{"price":"100","volume":"1"}],"bids":[{"price":"99","volume":"1"}]}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body + strings.Repeat(" ", 1024)))
+	}))
+	defer server.Close()
+
+	// Тело ответа длиннее допустимого не читается целиком и считается ошибкой
+	g, err := NewGarantex(config.ProviderConfig{URL: server.URL, HTTP: config.HTTPConfig{MaxBodySize: 512}})
+	assert.NoError(t, err)
+	_, err = g.FetchOrderBook(context.Background(), "usdtrub")
+	assert.ErrorIs(t, err, ErrResponseTooLarge)
+
+	g, err = NewGarantex(config.ProviderConfig{URL: server.URL, HTTP: config.HTTPConfig{MaxBodySize: 2048}})
+	assert.NoError(t, err)
+	_, err = g.FetchOrderBook(context.Background(), "usdtrub")
+	assert.NoError(t, err)
+}
+
+func TestGarantex_InvalidHTTPConfig(t *testing.T) {
+	_, err := NewGarantex(config.ProviderConfig{
+		HTTP: config.HTTPConfig{TLS: config.TLSConfig{CAFile: "/nonexistent/ca.pem"}},
+	})
+
+	assert.Error(t, err)
+}
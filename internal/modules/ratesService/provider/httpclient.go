@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"getUSDT/config"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+)
+
+const (
+	defaultDialTimeout         = 5 * time.Second
+	defaultTLSHandshakeTimeout = 5 * time.Second
+	defaultIdleConnTimeout     = 90 * time.Second
+	defaultMaxIdleConns        = 100
+	defaultMaxIdleConnsPerHost = 10
+	defaultMaxBodySize         = 1 << 20
+)
+
+// ErrResponseTooLarge возвращается, если тело ответа биржи превышает допустимый размер
+var ErrResponseTooLarge = errors.New("response body is too large")
+
+// newHTTPClient создает HTTP клиент провайдера по конфигурации.
+// Общий таймаут запроса задается контекстом, поэтому у клиента он не выставляется.
+func newHTTPClient(cfg config.HTTPConfig) (*http.Client, error) {
+	proxy := http.ProxyFromEnvironment
+	if cfg.Proxy != "" {
+		proxyURL, err := url.Parse(cfg.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy url %q: %w", cfg.Proxy, err)
+		}
+		proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig, err := newTLSConfig(cfg.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := &http.Transport{
+		Proxy: proxy,
+		DialContext: (&net.Dialer{
+			Timeout:   durationOr(cfg.DialTimeout, defaultDialTimeout),
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSClientConfig:       tlsConfig,
+		TLSHandshakeTimeout:   durationOr(cfg.TLSHandshakeTimeout, defaultTLSHandshakeTimeout),
+		ResponseHeaderTimeout: cfg.ResponseHeaderTimeout,
+		IdleConnTimeout:       durationOr(cfg.IdleConnTimeout, defaultIdleConnTimeout),
+		MaxIdleConns:          intOr(cfg.MaxIdleConns, defaultMaxIdleConns),
+		MaxIdleConnsPerHost:   intOr(cfg.MaxIdleConnsPerHost, defaultMaxIdleConnsPerHost),
+		MaxConnsPerHost:       cfg.MaxConnsPerHost,
+		ForceAttemptHTTP2:     true,
+	}
+
+	return &http.Client{Transport: transport}, nil
+}
+
+// newTLSConfig создает настройки TLS с дополнительным корневым сертификатом
+func newTLSConfig(cfg config.TLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         cfg.ServerName,
+		InsecureSkipVerify: cfg.InsecureSkipVerify, // Включается явно только для локальных стендов
+	}
+
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA file %q", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
+
+// durationOr возвращает значение или значение по умолчанию, если оно не задано
+func durationOr(value, fallback time.Duration) time.Duration {
+	if value > 0 {
+		return value
+	}
+	return fallback
+}
+
+// maxBodySize возвращает допустимый размер тела ответа по конфигурации
+func maxBodySize(cfg config.HTTPConfig) int64 {
+	if cfg.MaxBodySize > 0 {
+		return cfg.MaxBodySize
+	}
+	return defaultMaxBodySize
+}
+
+// readBody читает тело ответа не больше limit байт; более длинное тело считается ошибкой,
+// чтобы неисправная биржа не исчерпала память
+func readBody(body io.Reader, limit int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrResponseTooLarge, limit)
+	}
+	return data, nil
+}
+
+// intOr возвращает значение или значение по умолчанию, если оно не задано
+func intOr(value, fallback int) int {
+	if value > 0 {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: provider.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	models "getUSDT/internal/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRateProvider is a mock of RateProvider interface.
+type MockRateProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateProviderMockRecorder
+}
+
+// MockRateProviderMockRecorder is the mock recorder for MockRateProvider.
+type MockRateProviderMockRecorder struct {
+	mock *MockRateProvider
+}
+
+// NewMockRateProvider creates a new mock instance.
+func NewMockRateProvider(ctrl *gomock.Controller) *MockRateProvider {
+	mock := &MockRateProvider{ctrl: ctrl}
+	mock.recorder = &MockRateProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRateProvider) EXPECT() *MockRateProviderMockRecorder {
+	return m.recorder
+}
+
+// FetchOrderBook mocks base method.
+func (m *MockRateProvider) FetchOrderBook(ctx context.Context, market string) (*models.OrderBook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchOrderBook", ctx, market)
+	ret0, _ := ret[0].(*models.OrderBook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchOrderBook indicates an expected call of FetchOrderBook.
+func (mr *MockRateProviderMockRecorder) FetchOrderBook(ctx, market interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchOrderBook", reflect.TypeOf((*MockRateProvider)(nil).FetchOrderBook), ctx, market)
+}
+
+// Markets mocks base method.
+func (m *MockRateProvider) Markets() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Markets")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// Markets indicates an expected call of Markets.
+func (mr *MockRateProviderMockRecorder) Markets() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Markets", reflect.TypeOf((*MockRateProvider)(nil).Markets))
+}
+
+// Name mocks base method.
+func (m *MockRateProvider) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockRateProviderMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockRateProvider)(nil).Name))
+}
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"getUSDT/config"
+	"getUSDT/internal/models"
+	"getUSDT/internal/monitoring"
+)
+
+//go:generate mockgen -source=provider.go -destination=mocks/mock_provider.go -package=mocks
+
+// RateProvider интерфейс источника курсов (биржи)
+type RateProvider interface {
+	// Name возвращает имя провайдера
+	Name() string
+	// Markets возвращает список рынков, поддерживаемых провайдером
+	Markets() []string
+	// FetchOrderBook получает текущий стакан для указанного рынка
+	FetchOrderBook(ctx context.Context, market string) (*models.OrderBook, error)
+}
+
+// Factory создает провайдер по его конфигурации
+type Factory func(cfg config.ProviderConfig) (RateProvider, error)
+
+// factories реестр известных провайдеров по имени
+var factories = map[string]Factory{
+	GarantexName: NewGarantex,
+	ReplayName:   NewReplay,
+}
+
+// New создает провайдер по его конфигурации.
+// Если настроены повторы и автоматический выключатель, провайдер оборачивается ими.
+func New(cfg config.ProviderConfig) (RateProvider, error) {
+	factory, ok := factories[cfg.Name]
+	if !ok {
+		return nil, fmt.Errorf("unknown rate provider: %q", cfg.Name)
+	}
+	p, err := factory(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Retry.Attempts > 1 {
+		p = NewRetrying(p, cfg.Retry)
+	}
+	if cfg.Breaker.FailureThreshold > 0 {
+		p = NewCircuitBreaker(p, cfg.Breaker)
+	}
+	return p, nil
+}
+
+// NewAll создает все провайдеры, перечисленные в конфигурации
+func NewAll(cfg config.ExchangeConfig) ([]RateProvider, error) {
+	providers := make([]RateProvider, 0, len(cfg.Providers))
+	for _, providerCfg := range cfg.Providers {
+		p, err := New(providerCfg)
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, p)
+	}
+	return providers, nil
+}
+
+// Find возвращает провайдер с указанным именем
+func Find(providers []RateProvider, name string) (RateProvider, error) {
+	for _, p := range providers {
+		if p.Name() == name {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("rate provider %q is not configured", name)
+}
+
+// Select возвращает провайдер, выбранный в конфигурации.
+// Составной провайдер и провайдер с переключением собираются из уже созданных провайдеров.
+func Select(cfg config.ExchangeConfig, providers []RateProvider, metrics *monitoring.Metrics) (RateProvider, error) {
+	switch cfg.Provider {
+	case CompositeName:
+		members, err := findAll(providers, cfg.Composite.Providers)
+		if err != nil {
+			return nil, err
+		}
+		return NewComposite(members, cfg.Composite)
+	case FailoverName:
+		ordered, err := findAll(providers, cfg.Failover)
+		if err != nil {
+			return nil, err
+		}
+		return NewFailover(ordered, metrics)
+	default:
+		return Find(providers, cfg.Provider)
+	}
+}
+
+// findAll возвращает провайдеров с указанными именами в заданном порядке
+func findAll(providers []RateProvider, names []string) ([]RateProvider, error) {
+	found := make([]RateProvider, 0, len(names))
+	for _, name := range names {
+		p, err := Find(providers, name)
+		if err != nil {
+			return nil, err
+		}
+		found = append(found, p)
+	}
+	return found, nil
+}
+
+// DepthProviders возвращает провайдеров, чьи стаканы отражают реальную глубину рынка, в порядке предпочтения.
+// Составной провайдер возвращает синтетический стакан из одного уровня, поэтому вместо него
+// используются его участники, поддерживающие рынок.
+func DepthProviders(p RateProvider, market string) []RateProvider {
+	if c, ok := p.(*Composite); ok {
+		var members []RateProvider
+		for _, m := range c.members {
+			if Supports(m, market) {
+				members = append(members, m)
+			}
+		}
+		return members
+	}
+	if Supports(p, market) {
+		return []RateProvider{p}
+	}
+	return nil
+}
+
+// Supports проверяет, поддерживает ли провайдер указанный рынок
+func Supports(p RateProvider, market string) bool {
+	for _, m := range p.Markets() {
+		if m == market {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"getUSDT/config"
+	"getUSDT/internal/models"
+	"os"
+	"sort"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// ReplayName имя провайдера воспроизведения в конфигурации
+const ReplayName = "replay"
+
+// ErrReplayExhausted возвращается, когда сохраненные ответы рынка закончились
+var ErrReplayExhausted = errors.New("replay captures exhausted")
+
+// parsers разборщики сохраненных ответов по имени исходного провайдера
+var parsers = map[string]func(market string, statusCode int, body []byte) (*models.OrderBook, error){
+	GarantexName: parseGarantexDepth,
+}
+
+// Replay провайдер, воспроизводящий сохраненные ответы биржи.
+// Ответы проходят тот же разбор, что и ответы исходного провайдера.
+type Replay struct {
+	markets []string
+	loop    bool
+
+	mu       sync.Mutex
+	captures map[string][]Capture
+	next     map[string]int
+}
+
+// NewReplay создает провайдер воспроизведения по каталогу сохраненных ответов
+func NewReplay(cfg config.ProviderConfig) (RateProvider, error) {
+	dir := cfg.Replay.Dir
+	if dir == "" {
+		return nil, fmt.Errorf("%s: replay dir is not set", ReplayName)
+	}
+
+	markets := cfg.Markets
+	if len(markets) == 0 {
+		// Рынки определяются по подкаталогам сохраненных ответов
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, fmt.Errorf("%s: failed to read replay dir: %w", ReplayName, err)
+		}
+		for _, e := range entries {
+			if e.IsDir() {
+				markets = append(markets, e.Name())
+			}
+		}
+		sort.Strings(markets)
+	}
+
+	captures := make(map[string][]Capture, len(markets))
+	for _, market := range markets {
+		loaded, err := LoadCaptures(dir, market)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", ReplayName, err)
+		}
+		captures[market] = loaded
+	}
+
+	return &Replay{
+		markets:  markets,
+		loop:     cfg.Replay.Loop,
+		captures: captures,
+		next:     make(map[string]int, len(markets)),
+	}, nil
+}
+
+// Name возвращает имя провайдера
+func (r *Replay) Name() string {
+	return ReplayName
+}
+
+// Markets возвращает список рынков, для которых есть сохраненные ответы
+func (r *Replay) Markets() []string {
+	return r.markets
+}
+
+// FetchOrderBook возвращает стакан из очередного сохраненного ответа рынка
+func (r *Replay) FetchOrderBook(ctx context.Context, market string) (*models.OrderBook, error) {
+	tracer := otel.Tracer("getUSDT.provider")
+	_, span := tracer.Start(ctx, "Replay.FetchOrderBook")
+	defer span.End()
+
+	capture, err := r.nextCapture(market)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "No capture to replay")
+		return nil, err
+	}
+	span.SetAttributes(
+		attribute.String("replay.provider", capture.Provider),
+		attribute.String("replay.captured_at", capture.CapturedAt.String()),
+		attribute.Int("http.status_code", capture.StatusCode),
+	)
+
+	parse, ok := parsers[capture.Provider]
+	if !ok {
+		err := fmt.Errorf("%s: no parser for provider %q", ReplayName, capture.Provider)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Unknown capture provider")
+		return nil, err
+	}
+
+	book, err := parse(market, capture.StatusCode, []byte(capture.Body))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Invalid captured response")
+		return nil, err
+	}
+
+	span.SetStatus(codes.Ok, "Order book replayed")
+	return book, nil
+}
+
+// nextCapture возвращает очередной сохраненный ответ рынка
+func (r *Replay) nextCapture(market string) (*Capture, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	captures := r.captures[market]
+	i := r.next[market]
+	if i >= len(captures) {
+		if !r.loop || len(captures) == 0 {
+			return nil, fmt.Errorf("%s %s: %w", ReplayName, market, ErrReplayExhausted)
+		}
+		i = 0
+	}
+	r.next[market] = i + 1
+	return &captures[i], nil
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+	"getUSDT/config"
+	"getUSDT/internal/testutil/fakegarantex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaptureAndReplay(t *testing.T) {
+	exchange := fakegarantex.New()
+	defer exchange.Close()
+	exchange.Enqueue("usdtrub", fakegarantex.Prices("100.5", "99.5"), fakegarantex.Malformed())
+
+	dir := t.TempDir()
+	g, err := NewGarantex(config.ProviderConfig{
+		URL:     exchange.BaseURL(),
+		Capture: config.CaptureConfig{Enabled: true, Dir: dir},
+	})
+	require.NoError(t, err)
+
+	// Биржа отвечает корректным стаканом, затем некорректным JSON
+	live, err := g.FetchOrderBook(context.Background(), "usdtrub")
+	require.NoError(t, err)
+	_, liveErr := g.FetchOrderBook(context.Background(), "usdtrub")
+	require.Error(t, liveErr)
+
+	// Оба ответа сохранены вместе с заголовками
+	captures, err := LoadCaptures(dir, "usdtrub")
+	require.NoError(t, err)
+	require.Len(t, captures, 2)
+	assert.Equal(t, GarantexName, captures[0].Provider)
+	assert.Equal(t, 200, captures[0].StatusCode)
+	assert.Equal(t, "application/json", captures[0].Header.Get("Content-Type"))
+	assert.Contains(t, captures[1].Body, `"price": "100.5"`)
+
+	// Воспроизведение дает тот же стакан и ту же ошибку разбора
+	replay, err := NewReplay(config.ProviderConfig{Replay: config.ReplayConfig{Dir: dir}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"usdtrub"}, replay.Markets())
+
+	replayed, err := replay.FetchOrderBook(context.Background(), "usdtrub")
+	assert.NoError(t, err)
+	assert.Equal(t, live, replayed)
+
+	_, err = replay.FetchOrderBook(context.Background(), "usdtrub")
+	assert.EqualError(t, err, liveErr.Error())
+
+	_, err = replay.FetchOrderBook(context.Background(), "usdtrub")
+	assert.ErrorIs(t, err, ErrReplayExhausted)
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"getUSDT/config"
+	"getUSDT/internal/models"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	defaultInitialBackoff = 200 * time.Millisecond
+	defaultMaxBackoff     = 5 * time.Second
+)
+
+// StatusError ошибка биржи с HTTP статусом, отличным от 200
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("API returned non-200 status code: %d", e.StatusCode)
+}
+
+// Retrying провайдер, повторяющий запрос при временных ошибках с экспоненциальной задержкой
+type Retrying struct {
+	RateProvider
+	attempts       int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+}
+
+// NewRetrying оборачивает провайдер повторами запросов
+func NewRetrying(p RateProvider, cfg config.RetryConfig) *Retrying {
+	initialBackoff := cfg.InitialBackoff
+	if initialBackoff <= 0 {
+		initialBackoff = defaultInitialBackoff
+	}
+	maxBackoff := cfg.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultMaxBackoff
+	}
+
+	return &Retrying{
+		RateProvider:   p,
+		attempts:       cfg.Attempts,
+		initialBackoff: initialBackoff,
+		maxBackoff:     maxBackoff,
+	}
+}
+
+// FetchOrderBook запрашивает стакан, повторяя запрос при временных ошибках
+func (r *Retrying) FetchOrderBook(ctx context.Context, market string) (*models.OrderBook, error) {
+	span := trace.SpanFromContext(ctx)
+
+	for attempt := 1; ; attempt++ {
+		book, err := r.RateProvider.FetchOrderBook(ctx, market)
+		if err == nil {
+			return book, nil
+		}
+		// Таймаут отдельной попытки повторяем, пока не истек контекст вызова
+		retryable := IsRetryable(err) || (ctx.Err() == nil && errors.Is(err, context.DeadlineExceeded))
+		if attempt >= r.attempts || !retryable {
+			return nil, err
+		}
+
+		// Ждем с экспоненциальной задержкой и случайным смещением (full jitter)
+		delay := r.backoff(attempt)
+		// Не ждем повтора, который заведомо не успеет до дедлайна вызова
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= delay {
+			return nil, err
+		}
+		span.AddEvent("Retrying rate provider request", trace.WithAttributes(
+			attribute.String("provider", r.Name()),
+			attribute.Int("retry.attempt", attempt),
+			attribute.Int64("retry.delay_ms", delay.Milliseconds()),
+			attribute.String("error", err.Error()),
+		))
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("%w (last error: %v)", ctx.Err(), err)
+		case <-timer.C:
+		}
+	}
+}
+
+// backoff возвращает случайную задержку в пределах экспоненциально растущего окна
+func (r *Retrying) backoff(attempt int) time.Duration {
+	window := r.initialBackoff << (attempt - 1)
+	if window <= 0 || window > r.maxBackoff {
+		window = r.maxBackoff
+	}
+	return time.Duration(rand.Int63n(int64(window) + 1))
+}
+
+// IsRetryable сообщает, является ли ошибка провайдера временной.
+// Временными считаются таймауты сети, отказ и сброс соединения, обрыв ответа, а также статусы 5xx и 429.
+// Остальные ошибки запроса, например неверный сертификат или адрес, повтор не исправит.
+func IsRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= http.StatusInternalServerError ||
+			statusErr.StatusCode == http.StatusTooManyRequests
+	}
+
+	// *url.Error реализует net.Error для любой ошибки запроса, поэтому проверяем только таймаут
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"getUSDT/config"
+	"getUSDT/internal/models"
+	"getUSDT/internal/modules/ratesService/provider/mocks"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var testRetryConfig = config.RetryConfig{
+	Attempts:       3,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     time.Millisecond,
+}
+
+func TestRetrying_RetriesTransientErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Две ошибки 503, затем успешный ответ
+	m := mocks.NewMockRateProvider(ctrl)
+	m.EXPECT().Name().Return("a").AnyTimes()
+	gomock.InOrder(
+		m.EXPECT().FetchOrderBook(gomock.Any(), "usdtrub").Return(nil, &StatusError{StatusCode: 503}),
+		m.EXPECT().FetchOrderBook(gomock.Any(), "usdtrub").Return(nil, &StatusError{StatusCode: 429}),
+		m.EXPECT().FetchOrderBook(gomock.Any(), "usdtrub").Return(&models.OrderBook{Source: "a"}, nil),
+	)
+
+	book, err := NewRetrying(m, testRetryConfig).FetchOrderBook(context.Background(), "usdtrub")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "a", book.Source)
+}
+
+func TestRetrying_DoesNotRetryPermanentErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Ошибка 400 не повторяется
+	m := mocks.NewMockRateProvider(ctrl)
+	m.EXPECT().Name().Return("a").AnyTimes()
+	m.EXPECT().FetchOrderBook(gomock.Any(), "usdtrub").Return(nil, &StatusError{StatusCode: 400}).Times(1)
+
+	_, err := NewRetrying(m, testRetryConfig).FetchOrderBook(context.Background(), "usdtrub")
+
+	var statusErr *StatusError
+	assert.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, 400, statusErr.StatusCode)
+}
+
+func TestRetrying_GivesUpAfterAttempts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := mocks.NewMockRateProvider(ctrl)
+	m.EXPECT().Name().Return("a").AnyTimes()
+	m.EXPECT().FetchOrderBook(gomock.Any(), "usdtrub").Return(nil, &StatusError{StatusCode: 502}).Times(3)
+
+	_, err := NewRetrying(m, testRetryConfig).FetchOrderBook(context.Background(), "usdtrub")
+
+	assert.Error(t, err)
+}
+
+// timeoutError сетевая ошибка таймаута
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestIsRetryable(t *testing.T) {
+	// Ошибки запроса оборачиваются клиентом в *url.Error
+	requestErr := func(err error) error {
+		return &url.Error{Op: "Get", URL: "https://garantex.org/api/v2/depth", Err: err}
+	}
+	dialErr := func(errno syscall.Errno) error {
+		return requestErr(&net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", errno)})
+	}
+
+	tests := []struct {
+		name      string
+		err       error
+		retryable bool
+	}{
+		{name: "status 500", err: &StatusError{StatusCode: 500}, retryable: true},
+		{name: "status 429", err: &StatusError{StatusCode: 429}, retryable: true},
+		{name: "status 404", err: &StatusError{StatusCode: 404}, retryable: false},
+		{name: "canceled", err: context.Canceled, retryable: false},
+		{name: "parse error", err: errors.New("invalid ask price"), retryable: false},
+		{name: "timeout", err: requestErr(timeoutError{}), retryable: true},
+		{name: "connection refused", err: dialErr(syscall.ECONNREFUSED), retryable: true},
+		{name: "connection reset", err: dialErr(syscall.ECONNRESET), retryable: true},
+		{name: "unexpected EOF", err: requestErr(io.ErrUnexpectedEOF), retryable: true},
+		{name: "unknown certificate authority", err: requestErr(x509.UnknownAuthorityError{}), retryable: false},
+		{name: "certificate hostname mismatch", err: requestErr(x509.HostnameError{Host: "garantex.org"}), retryable: false},
+		{name: "unsupported scheme", err: requestErr(errors.New(`unsupported protocol scheme "ftp"`)), retryable: false},
+		{name: "invalid URL", err: requestErr(url.InvalidHostError("garantex org")), retryable: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.retryable, IsRetryable(tt.err))
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"getUSDT/internal/models"
+	"sync"
+	"time"
+)
+
+// rateCache кэш последних курсов по рынкам с ограниченным временем жизни
+type rateCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.RWMutex
+	entries map[string]cacheEntry
+}
+
+// cacheEntry курс и момент истечения его актуальности
+type cacheEntry struct {
+	rate    *models.Rate
+	expires time.Time
+}
+
+// newRateCache создает кэш курсов. Нулевой ttl отключает кэширование.
+func newRateCache(ttl time.Duration) *rateCache {
+	return &rateCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+// Get возвращает закэшированный курс рынка, если он еще не истек
+func (c *rateCache) Get(market string) (*models.Rate, bool) {
+	if c.ttl <= 0 {
+		return nil, false
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[market]
+	if !ok || !c.now().Before(entry.expires) {
+		return nil, false
+	}
+	return entry.rate, true
+}
+
+// Set сохраняет курс рынка в кэш
+func (c *rateCache) Set(market string, rate *models.Rate) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[market] = cacheEntry{rate: rate, expires: c.now().Add(c.ttl)}
+}
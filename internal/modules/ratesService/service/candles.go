@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"getUSDT/internal/models"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// maxCandles максимальное количество свечей в одном ответе
+const maxCandles = 5000
+
+// candleIntervals поддерживаемые интервалы свечей
+var candleIntervals = map[string]time.Duration{
+	"1m": time.Minute,
+	"5m": 5 * time.Minute,
+	"1h": time.Hour,
+	"1d": 24 * time.Hour,
+}
+
+// GetCandles возвращает свечи рынка указанного интервала за период [from, to).
+// Нулевой to означает текущий момент, нулевой from — последние maxCandles свечей до to.
+// Пустые интервалы заполняются ценой закрытия предыдущей свечи и имеют нулевое количество курсов.
+// Пустые интервалы в начале периода заполняются ценой последнего курса до from;
+// если такого курса нет, свечи начинаются с первого интервала с данными.
+func (s *RatesService) GetCandles(ctx context.Context, market, interval string, from, to time.Time) ([]models.Candle, error) {
+	// Создаем трассировщик для отслеживания выполнения этой операции
+	tracer := otel.Tracer("getUSDT.service")
+	ctx, span := tracer.Start(ctx, "GetCandles")
+	defer span.End()
+
+	// Проверяем и нормализуем параметры запроса
+	market, err := s.resolveMarket(market)
+	if err != nil {
+		return nil, err
+	}
+	step, ok := candleIntervals[interval]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported candle interval %q", models.ErrInvalidArgument, interval)
+	}
+	now := time.Now()
+	if to.IsZero() || to.After(now) {
+		to = now
+	}
+	if from.IsZero() {
+		// Без начала периода отдаются последние свечи, которые помещаются в один ответ
+		from = to.Add(-(maxCandles - 1) * step)
+	}
+	from = alignToInterval(from, step)
+	if !from.Before(to) {
+		return nil, fmt.Errorf("%w: from must be before to", models.ErrInvalidArgument)
+	}
+	if to.Sub(from)/step >= maxCandles {
+		return nil, fmt.Errorf("%w: period exceeds %d candles", models.ErrInvalidArgument, maxCandles)
+	}
+
+	span.SetAttributes(
+		attribute.String("candles.market", market),
+		attribute.String("candles.interval", interval),
+		attribute.String("candles.from", from.Format(time.RFC3339)),
+		attribute.String("candles.to", to.Format(time.RFC3339)),
+	)
+
+	candles, err := s.storage.GetCandles(ctx, market, step, from, to)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to get candles")
+		return nil, fmt.Errorf("failed to get candles: %w", err)
+	}
+
+	// Последний курс до начала периода задает цену пустых свечей в начале
+	var seed *models.Candle
+	before, err := s.storage.GetRateBefore(ctx, market, from)
+	switch {
+	case err == nil:
+		candle := rateCandle(before, from)
+		seed = &candle
+	case !errors.Is(err, models.ErrRateNotFound):
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to get rate before period")
+		return nil, fmt.Errorf("failed to get rate before period: %w", err)
+	}
+
+	candles = fillCandleGaps(candles, seed, step, to)
+
+	span.SetAttributes(attribute.Int("candles.count", len(candles)))
+	span.SetStatus(codes.Ok, "Candles fetched successfully")
+	return candles, nil
+}
+
+// alignToInterval выравнивает время вниз по границе интервала от UNIX-эпохи
+func alignToInterval(t time.Time, step time.Duration) time.Time {
+	seconds := int64(step / time.Second)
+	return time.Unix(t.Unix()/seconds*seconds, 0)
+}
+
+// fillCandleGaps дополняет отсортированные свечи пустыми интервалами вплоть до to.
+// Пустая свеча повторяет цену закрытия предыдущей свечи по всем значениям OHLC.
+// seed — пустая свеча первого интервала периода по цене последнего курса до него, может быть nil.
+func fillCandleGaps(candles []models.Candle, seed *models.Candle, step time.Duration, to time.Time) []models.Candle {
+	filled := make([]models.Candle, 0, len(candles))
+	// Первая свеча с данными в начале периода заменяет пустую
+	if seed != nil && (len(candles) == 0 || seed.Start.Before(candles[0].Start)) {
+		filled = append(filled, *seed)
+	}
+	for _, candle := range candles {
+		if len(filled) > 0 {
+			prev := filled[len(filled)-1]
+			for start := prev.Start.Add(step); start.Before(candle.Start); start = start.Add(step) {
+				filled = append(filled, flatCandle(prev, start))
+			}
+		}
+		filled = append(filled, candle)
+	}
+	if len(filled) == 0 {
+		return candles
+	}
+
+	// Дополняем пустыми свечами конец периода
+	last := filled[len(filled)-1]
+	for start := last.Start.Add(step); start.Before(to); start = start.Add(step) {
+		filled = append(filled, flatCandle(last, start))
+	}
+
+	return filled
+}
+
+// rateCandle создает пустую свечу по ценам курса
+func rateCandle(rate *models.Rate, start time.Time) models.Candle {
+	mid := rate.Ask.Add(rate.Bid).Div(decimal.NewFromInt(2))
+	return flatCandle(models.Candle{
+		Ask: models.OHLC{Close: rate.Ask},
+		Bid: models.OHLC{Close: rate.Bid},
+		Mid: models.OHLC{Close: mid},
+	}, start)
+}
+
+// flatCandle создает пустую свечу по цене закрытия предыдущей
+func flatCandle(prev models.Candle, start time.Time) models.Candle {
+	flat := func(ohlc models.OHLC) models.OHLC {
+		return models.OHLC{Open: ohlc.Close, High: ohlc.Close, Low: ohlc.Close, Close: ohlc.Close}
+	}
+	return models.Candle{
+		Start: start,
+		Ask:   flat(prev.Ask),
+		Bid:   flat(prev.Bid),
+		Mid:   flat(prev.Mid),
+	}
+}
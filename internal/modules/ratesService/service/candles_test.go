@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"getUSDT/config"
+	"getUSDT/internal/models"
+	"getUSDT/internal/modules/ratesService/service/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCandles_FillsEmptyBuckets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockRatesStorage(ctrl)
+
+	from := time.Unix(1700000000, 0).Truncate(time.Minute)
+	to := from.Add(4 * time.Minute)
+
+	// В хранилище есть данные только для первой и третьей минуты
+	stored := []models.Candle{
+		{
+			Start: from,
+			Ask:   models.OHLC{Open: dec("100"), High: dec("102"), Low: dec("99"), Close: dec("101")},
+			Bid:   models.OHLC{Open: dec("98"), High: dec("100"), Low: dec("97"), Close: dec("99")},
+			Mid:   models.OHLC{Open: dec("99"), High: dec("101"), Low: dec("98"), Close: dec("100")},
+			Count: 3,
+		},
+		{
+			Start: from.Add(2 * time.Minute),
+			Ask:   models.OHLC{Open: dec("103"), High: dec("103"), Low: dec("103"), Close: dec("103")},
+			Bid:   models.OHLC{Open: dec("101"), High: dec("101"), Low: dec("101"), Close: dec("101")},
+			Mid:   models.OHLC{Open: dec("102"), High: dec("102"), Low: dec("102"), Close: dec("102")},
+			Count: 1,
+		},
+	}
+	mockStorage.EXPECT().GetCandles(gomock.Any(), "usdtrub", time.Minute, from, to).Return(stored, nil).Times(1)
+	mockStorage.EXPECT().GetRateBefore(gomock.Any(), "usdtrub", from).Return(nil, models.ErrRateNotFound).Times(1)
+
+	service := NewRatesService(mockStorage, nil, config.RatesConfig{}, nil)
+
+	candles, err := service.GetCandles(context.Background(), "", "1m", from, to)
+
+	assert.NoError(t, err)
+	assert.Len(t, candles, 4)
+
+	// Пустые интервалы повторяют цену закрытия предыдущей свечи
+	assert.Equal(t, from.Add(time.Minute), candles[1].Start)
+	assert.Equal(t, int64(0), candles[1].Count)
+	assert.Equal(t, models.OHLC{Open: dec("101"), High: dec("101"), Low: dec("101"), Close: dec("101")}, candles[1].Ask)
+	assert.Equal(t, models.OHLC{Open: dec("100"), High: dec("100"), Low: dec("100"), Close: dec("100")}, candles[1].Mid)
+	assert.Equal(t, stored[1], candles[2])
+	assert.Equal(t, from.Add(3*time.Minute), candles[3].Start)
+	assert.Equal(t, "103", candles[3].Ask.Open.String())
+}
+
+func TestGetCandles_LeadingEmptyBuckets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockRatesStorage(ctrl)
+
+	from := time.Unix(1700000000, 0).Truncate(time.Minute)
+	to := from.Add(4 * time.Minute)
+
+	// Первый курс в периоде появился только на третьей минуте
+	stored := []models.Candle{
+		{
+			Start: from.Add(2 * time.Minute),
+			Ask:   models.OHLC{Open: dec("103"), High: dec("103"), Low: dec("103"), Close: dec("103")},
+			Bid:   models.OHLC{Open: dec("101"), High: dec("101"), Low: dec("101"), Close: dec("101")},
+			Mid:   models.OHLC{Open: dec("102"), High: dec("102"), Low: dec("102"), Close: dec("102")},
+			Count: 1,
+		},
+	}
+	mockStorage.EXPECT().GetCandles(gomock.Any(), "usdtrub", time.Minute, from, to).Return(stored, nil).Times(1)
+	mockStorage.EXPECT().GetRateBefore(gomock.Any(), "usdtrub", from).
+		Return(&models.Rate{Market: "usdtrub", Ask: dec("100"), Bid: dec("98")}, nil).Times(1)
+
+	service := NewRatesService(mockStorage, nil, config.RatesConfig{}, nil)
+
+	candles, err := service.GetCandles(context.Background(), "", "1m", from, to)
+
+	assert.NoError(t, err)
+	assert.Len(t, candles, 4)
+
+	// Пустые интервалы в начале периода повторяют последний курс до from
+	for i, candle := range candles[:2] {
+		assert.Equal(t, from.Add(time.Duration(i)*time.Minute), candle.Start)
+		assert.Equal(t, int64(0), candle.Count)
+		assert.Equal(t, "100", candle.Ask.Open.String())
+		assert.Equal(t, "98", candle.Bid.Close.String())
+		assert.Equal(t, "99", candle.Mid.High.String())
+	}
+	assert.Equal(t, stored[0], candles[2])
+	assert.Equal(t, "103", candles[3].Ask.Close.String())
+}
+
+func TestGetCandles_NoRatesBeforePeriod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockRatesStorage(ctrl)
+
+	from := time.Unix(1700000000, 0).Truncate(time.Minute)
+	to := from.Add(4 * time.Minute)
+
+	stored := []models.Candle{
+		{
+			Start: from.Add(2 * time.Minute),
+			Ask:   models.OHLC{Open: dec("103"), High: dec("103"), Low: dec("103"), Close: dec("103")},
+			Count: 1,
+		},
+	}
+	mockStorage.EXPECT().GetCandles(gomock.Any(), "usdtrub", time.Minute, from, to).Return(stored, nil).Times(1)
+	mockStorage.EXPECT().GetRateBefore(gomock.Any(), "usdtrub", from).Return(nil, models.ErrRateNotFound).Times(1)
+
+	service := NewRatesService(mockStorage, nil, config.RatesConfig{}, nil)
+
+	candles, err := service.GetCandles(context.Background(), "", "1m", from, to)
+
+	// Без курса до from цену пустых интервалов в начале периода взять неоткуда
+	assert.NoError(t, err)
+	assert.Len(t, candles, 2)
+	assert.Equal(t, stored[0], candles[0])
+}
+
+func TestGetCandles_DefaultFrom(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockRatesStorage(ctrl)
+
+	to := time.Unix(1700000000, 0).Truncate(time.Hour)
+	from := to.Add(-(maxCandles - 1) * time.Hour)
+	mockStorage.EXPECT().GetCandles(gomock.Any(), "usdtrub", time.Hour, from, to).Return(nil, nil).Times(1)
+	mockStorage.EXPECT().GetRateBefore(gomock.Any(), "usdtrub", from).Return(nil, models.ErrRateNotFound).Times(1)
+
+	service := NewRatesService(mockStorage, nil, config.RatesConfig{}, nil)
+
+	// Без начала периода запрашиваются последние свечи, помещающиеся в один ответ
+	candles, err := service.GetCandles(context.Background(), "", "1h", time.Time{}, to)
+
+	assert.NoError(t, err)
+	assert.Empty(t, candles)
+}
+
+func TestGetCandles_InvalidInterval(t *testing.T) {
+	service := NewRatesService(nil, nil, config.RatesConfig{}, nil)
+
+	_, err := service.GetCandles(context.Background(), "usdtrub", "2m", time.Now().Add(-time.Hour), time.Time{})
+
+	assert.ErrorIs(t, err, models.ErrInvalidArgument)
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"getUSDT/internal/models"
+	"sync"
+	"time"
+)
+
+// flight общий запрос курса одного рынка, который ждут несколько вызывающих.
+// Запрос выполняется с собственным контекстом: он ограничен самым поздним дедлайном
+// из ожидающих и отменяется, когда ожидающих не осталось.
+type flight struct {
+	done chan struct{} // Закрывается после получения результата
+	rate *models.Rate
+	err  error
+
+	waiters   int
+	ctx       context.Context
+	cancel    context.CancelFunc
+	timer     *time.Timer // Отменяет запрос по самому позднему дедлайну ожидающих
+	deadline  time.Time
+	unbounded bool // Один из ожидающих не ограничен дедлайном
+	finished  bool
+}
+
+// flights общие запросы курсов по рынкам
+type flights struct {
+	mu    sync.Mutex
+	byKey map[string]*flight
+}
+
+// join присоединяет вызывающего к текущему запросу рынка или запускает новый.
+// Возвращает запрос и признак того, что он уже выполнялся для других вызывающих.
+// После получения результата или отмены вызывающий обязан вызвать leave.
+func (s *RatesService) join(ctx context.Context, market string) (*flight, bool) {
+	s.flights.mu.Lock()
+	defer s.flights.mu.Unlock()
+
+	// К запросу, отмененному по дедлайну, не присоединяемся: он уже не вернет курс
+	if f, ok := s.flights.byKey[market]; ok && f.ctx.Err() == nil {
+		f.waiters++
+		f.extend(ctx)
+		return f, true
+	}
+
+	// Значения контекста, например трассировка, сохраняются, а отмена и дедлайн — нет
+	fetchCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	f := &flight{done: make(chan struct{}), waiters: 1, ctx: fetchCtx, cancel: cancel}
+	f.extend(ctx)
+	if s.flights.byKey == nil {
+		s.flights.byKey = make(map[string]*flight)
+	}
+	s.flights.byKey[market] = f
+
+	go s.run(fetchCtx, market, f)
+	return f, false
+}
+
+// leave отмечает, что вызывающий больше не ждет результата.
+// Запрос без ожидающих отменяется, и следующий вызов запускает новый.
+func (s *RatesService) leave(market string, f *flight) {
+	s.flights.mu.Lock()
+	defer s.flights.mu.Unlock()
+
+	f.waiters--
+	if f.waiters > 0 || f.finished {
+		return
+	}
+	if s.flights.byKey[market] == f {
+		delete(s.flights.byKey, market)
+	}
+	f.stop()
+}
+
+// run выполняет общий запрос и публикует результат ожидающим
+func (s *RatesService) run(ctx context.Context, market string, f *flight) {
+	rate, err := s.loadRate(ctx, market)
+	// Устаревший курс, отданный при недоступности биржи, не кэшируется
+	if err == nil && time.Since(rate.Timestamp) <= s.maxAge {
+		s.cache.Set(market, rate)
+	}
+
+	s.flights.mu.Lock()
+	defer s.flights.mu.Unlock()
+
+	if s.flights.byKey[market] == f {
+		delete(s.flights.byKey, market)
+	}
+	f.rate, f.err = rate, err
+	f.finished = true
+	f.stop()
+	close(f.done)
+}
+
+// extend продлевает запрос до дедлайна нового ожидающего.
+// Вызывается под блокировкой flights.
+func (f *flight) extend(ctx context.Context) {
+	if f.unbounded {
+		return
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		// Запрос остается ограничен таймаутом провайдера
+		f.unbounded = true
+		if f.timer != nil {
+			f.timer.Stop()
+		}
+		return
+	}
+	if !deadline.After(f.deadline) {
+		return
+	}
+
+	f.deadline = deadline
+	if f.timer == nil {
+		f.timer = time.AfterFunc(time.Until(deadline), f.cancel)
+		return
+	}
+	f.timer.Reset(time.Until(deadline))
+}
+
+// stop отменяет контекст запроса и его таймер.
+// Вызывается под блокировкой flights.
+func (f *flight) stop() {
+	if f.timer != nil {
+		f.timer.Stop()
+	}
+	f.cancel()
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"getUSDT/config"
+	"getUSDT/internal/models"
+	providermocks "getUSDT/internal/modules/ratesService/provider/mocks"
+	"getUSDT/internal/modules/ratesService/service/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRates_SharedFetchCancelledWhenAllCallersLeave(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProvider := providermocks.NewMockRateProvider(ctrl)
+	mockProvider.EXPECT().Name().Return("garantex").AnyTimes()
+	mockProvider.EXPECT().Markets().Return([]string{"usdtrub"}).AnyTimes()
+
+	// Биржа не отвечает, пока запрос не отменят
+	started := make(chan struct{})
+	cancelled := make(chan error, 1)
+	mockProvider.EXPECT().FetchOrderBook(gomock.Any(), "usdtrub").DoAndReturn(
+		func(ctx context.Context, market string) (*models.OrderBook, error) {
+			close(started)
+			<-ctx.Done()
+			cancelled <- ctx.Err()
+			return nil, ctx.Err()
+		}).Times(1)
+
+	service := NewRatesService(mocks.NewMockRatesStorage(ctrl), mockProvider, config.RatesConfig{}, nil)
+
+	first, cancelFirst := context.WithCancel(context.Background())
+	second, cancelSecond := context.WithCancel(context.Background())
+	errs := make(chan error, 2)
+	go func() {
+		_, err := service.GetRates(first, "")
+		errs <- err
+	}()
+	<-started
+	go func() {
+		_, err := service.GetRates(second, "")
+		errs <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	// Уход первого вызывающего не отменяет запрос, который ждет второй
+	cancelFirst()
+	assert.ErrorIs(t, <-errs, context.Canceled)
+	select {
+	case <-cancelled:
+		t.Fatal("shared fetch cancelled while a caller is still waiting")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// После ухода последнего вызывающего запрос к бирже отменяется
+	cancelSecond()
+	assert.ErrorIs(t, <-errs, context.Canceled)
+	select {
+	case err := <-cancelled:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("shared fetch not cancelled after all callers left")
+	}
+}
+
+func TestGetRates_SharedFetchBoundedByLatestDeadline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockRatesStorage(ctrl)
+	mockProvider := providermocks.NewMockRateProvider(ctrl)
+	mockProvider.EXPECT().Name().Return("garantex").AnyTimes()
+	mockProvider.EXPECT().Markets().Return([]string{"usdtrub"}).AnyTimes()
+
+	// Биржа отвечает позже дедлайна первого вызывающего, но раньше дедлайна второго
+	started := make(chan struct{})
+	mockProvider.EXPECT().FetchOrderBook(gomock.Any(), "usdtrub").DoAndReturn(
+		func(ctx context.Context, market string) (*models.OrderBook, error) {
+			close(started)
+			select {
+			case <-time.After(150 * time.Millisecond):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			return &models.OrderBook{
+				Asks: []models.OrderBookLevel{{Price: "100.5"}},
+				Bids: []models.OrderBookLevel{{Price: "99.5"}},
+			}, nil
+		}).Times(1)
+	mockStorage.EXPECT().SaveRate(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	service := NewRatesService(mockStorage, mockProvider, config.RatesConfig{}, nil)
+
+	short, cancelShort := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancelShort()
+	long, cancelLong := context.WithTimeout(context.Background(), time.Second)
+	defer cancelLong()
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := service.GetRates(short, "")
+		errs <- err
+	}()
+	<-started
+
+	rate, err := service.GetRates(long, "")
+
+	// Общий запрос продлен до дедлайна второго вызывающего
+	require.NoError(t, err)
+	assert.Equal(t, "100.5", rate.Ask.String())
+	assert.ErrorIs(t, <-errs, context.DeadlineExceeded)
+}
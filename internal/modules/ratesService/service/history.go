@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"getUSDT/internal/models"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+const (
+	defaultHistoryLimit = 100
+	maxHistoryLimit     = 1000
+)
+
+// GetRateHistory возвращает страницу истории курсов рынка за период [from, to)
+func (s *RatesService) GetRateHistory(ctx context.Context, market string, from, to time.Time, limit int, pageToken string) (*models.RateHistoryPage, error) {
+	// Создаем трассировщик для отслеживания выполнения этой операции
+	tracer := otel.Tracer("getUSDT.service")
+	ctx, span := tracer.Start(ctx, "GetRateHistory")
+	defer span.End()
+
+	// Проверяем и нормализуем параметры запроса
+	market, err := s.resolveMarket(market)
+	if err != nil {
+		return nil, err
+	}
+	if to.IsZero() {
+		to = time.Now()
+	}
+	if !from.Before(to) {
+		return nil, fmt.Errorf("%w: from must be before to", models.ErrInvalidArgument)
+	}
+	switch {
+	case limit < 0:
+		return nil, fmt.Errorf("%w: limit must not be negative", models.ErrInvalidArgument)
+	case limit == 0:
+		limit = defaultHistoryLimit
+	case limit > maxHistoryLimit:
+		limit = maxHistoryLimit
+	}
+
+	filter := models.RateHistoryFilter{Market: market, From: from, To: to, Limit: limit + 1}
+	if pageToken != "" {
+		cursor, err := decodePageToken(pageToken)
+		if err != nil {
+			return nil, err
+		}
+		filter.After = cursor
+	}
+
+	span.SetAttributes(
+		attribute.String("history.market", market),
+		attribute.String("history.from", from.Format(time.RFC3339)),
+		attribute.String("history.to", to.Format(time.RFC3339)),
+		attribute.Int("history.limit", limit),
+	)
+
+	// Запрашиваем на одну запись больше, чтобы понять, есть ли следующая страница
+	rates, err := s.storage.GetRateHistory(ctx, filter)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to get rate history")
+		return nil, fmt.Errorf("failed to get rate history: %w", err)
+	}
+
+	page := &models.RateHistoryPage{Rates: rates}
+	if len(rates) > limit {
+		page.Rates = rates[:limit]
+		last := page.Rates[limit-1]
+		page.NextPageToken = encodePageToken(models.RateCursor{Timestamp: last.Timestamp, ID: last.ID})
+	}
+
+	span.SetAttributes(attribute.Int("history.count", len(page.Rates)))
+	span.SetStatus(codes.Ok, "Rate history fetched successfully")
+	return page, nil
+}
+
+// encodePageToken кодирует позицию в истории в непрозрачный токен
+func encodePageToken(cursor models.RateCursor) string {
+	raw := fmt.Sprintf("%d:%d", cursor.Timestamp.UnixNano(), cursor.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodePageToken восстанавливает позицию в истории из токена
+func decodePageToken(token string) (*models.RateCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed page token", models.ErrInvalidArgument)
+	}
+
+	parts := strings.SplitN(string(raw), ":", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("%w: malformed page token", models.ErrInvalidArgument)
+	}
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed page token", models.ErrInvalidArgument)
+	}
+	id, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed page token", models.ErrInvalidArgument)
+	}
+
+	return &models.RateCursor{Timestamp: time.Unix(0, nanos), ID: id}, nil
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"getUSDT/config"
+	"getUSDT/internal/models"
+	"getUSDT/internal/modules/ratesService/service/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRateHistory_NextPage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockRatesStorage(ctrl)
+
+	from := time.Unix(1700000000, 0)
+	to := from.Add(time.Hour)
+	stored := []models.Rate{
+		{ID: 1, Ask: dec("100"), Bid: dec("99"), Timestamp: from},
+		{ID: 2, Ask: dec("101"), Bid: dec("100"), Timestamp: from.Add(time.Minute)},
+		{ID: 3, Ask: dec("102"), Bid: dec("101"), Timestamp: from.Add(2 * time.Minute)},
+	}
+
+	// Хранилище запрашивается с лимитом на одну запись больше страницы
+	mockStorage.EXPECT().GetRateHistory(gomock.Any(), models.RateHistoryFilter{Market: "usdtrub", From: from, To: to, Limit: 3}).
+		Return(stored, nil).Times(1)
+
+	service := NewRatesService(mockStorage, nil, config.RatesConfig{}, nil)
+
+	page, err := service.GetRateHistory(context.Background(), "usdtrub", from, to, 2, "")
+
+	assert.NoError(t, err)
+	assert.Len(t, page.Rates, 2)
+	assert.NotEmpty(t, page.NextPageToken)
+
+	// Токен указывает на последнюю выданную запись
+	cursor, err := decodePageToken(page.NextPageToken)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), cursor.ID)
+	assert.True(t, cursor.Timestamp.Equal(stored[1].Timestamp))
+}
+
+func TestGetRateHistory_InvalidArguments(t *testing.T) {
+	service := NewRatesService(nil, nil, config.RatesConfig{}, nil)
+	from := time.Unix(1700000000, 0)
+
+	_, err := service.GetRateHistory(context.Background(), "usdtrub", from, from.Add(-time.Hour), 10, "")
+	assert.ErrorIs(t, err, models.ErrInvalidArgument)
+
+	_, err = service.GetRateHistory(context.Background(), "usdtrub", from, from.Add(time.Hour), 10, "not a token")
+	assert.ErrorIs(t, err, models.ErrInvalidArgument)
+}
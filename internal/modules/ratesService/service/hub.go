@@ -0,0 +1,84 @@
+package service
+
+import (
+	"getUSDT/internal/models"
+	"sync"
+)
+
+// subscriberBuffer размер буфера канала каждого подписчика
+const subscriberBuffer = 16
+
+// Hub рассылает сохраненные курсы всем подписчикам
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[chan *models.Rate]struct{}
+	closed      bool
+}
+
+// NewHub создает новый Hub
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: make(map[chan *models.Rate]struct{}),
+	}
+}
+
+// Subscribe регистрирует подписчика и возвращает канал с курсами и функцию отписки.
+// Канал закрывается после отписки или остановки Hub.
+func (h *Hub) Subscribe() (<-chan *models.Rate, func()) {
+	ch := make(chan *models.Rate, subscriberBuffer)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// После остановки новые подписчики сразу получают закрытый канал
+	if h.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	h.subscribers[ch] = struct{}{}
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() { h.remove(ch) })
+	}
+}
+
+// Publish отправляет курс всем подписчикам.
+// Подписчик, не успевающий вычитывать канал, пропускает курс, чтобы не блокировать остальных.
+func (h *Hub) Publish(rate *models.Rate) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for ch := range h.subscribers {
+		select {
+		case ch <- rate:
+		default:
+		}
+	}
+}
+
+// Close закрывает каналы всех подписчиков и запрещает новые подписки
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return
+	}
+	h.closed = true
+	for ch := range h.subscribers {
+		delete(h.subscribers, ch)
+		close(ch)
+	}
+}
+
+// remove удаляет подписчика и закрывает его канал
+func (h *Hub) remove(ch chan *models.Rate) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.subscribers[ch]; ok {
+		delete(h.subscribers, ch)
+		close(ch)
+	}
+}
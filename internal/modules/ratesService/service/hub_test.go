@@ -0,0 +1,60 @@
+package service
+
+import (
+	"getUSDT/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHub_PublishToAllSubscribers(t *testing.T) {
+	hub := NewHub()
+
+	first, unsubscribeFirst := hub.Subscribe()
+	second, unsubscribeSecond := hub.Subscribe()
+	defer unsubscribeSecond()
+
+	rate := &models.Rate{Ask: dec("100.5"), Bid: dec("99.5")}
+	hub.Publish(rate)
+
+	// Курс получают все подписчики
+	assert.Equal(t, rate, <-first)
+	assert.Equal(t, rate, <-second)
+
+	// После отписки канал закрывается и больше не получает курсы
+	unsubscribeFirst()
+	hub.Publish(rate)
+	_, ok := <-first
+	assert.False(t, ok)
+	assert.Equal(t, rate, <-second)
+}
+
+func TestHub_SlowSubscriberDoesNotBlock(t *testing.T) {
+	hub := NewHub()
+
+	rates, unsubscribe := hub.Subscribe()
+	defer unsubscribe()
+
+	// Публикаций больше, чем вмещает буфер подписчика
+	for i := 0; i < subscriberBuffer*2; i++ {
+		hub.Publish(&models.Rate{ID: int64(i)})
+	}
+
+	assert.Len(t, rates, subscriberBuffer)
+}
+
+func TestHub_CloseEndsSubscriptions(t *testing.T) {
+	hub := NewHub()
+
+	rates, unsubscribe := hub.Subscribe()
+	hub.Close()
+
+	_, ok := <-rates
+	assert.False(t, ok)
+
+	// Повторная отписка и подписка после остановки безопасны
+	unsubscribe()
+	late, _ := hub.Subscribe()
+	_, ok = <-late
+	assert.False(t, ok)
+}
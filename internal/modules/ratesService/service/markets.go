@@ -0,0 +1,38 @@
+package service
+
+import (
+	"fmt"
+	"getUSDT/internal/models"
+	"getUSDT/internal/modules/ratesService/provider"
+)
+
+// ListMarkets возвращает включенные рынки и провайдеров, которые их поддерживают
+func (s *RatesService) ListMarkets() []models.Market {
+	markets := make([]models.Market, 0, len(s.markets))
+	for _, name := range s.markets {
+		market := models.Market{Name: name, Providers: []string{}}
+		if s.provider != nil && provider.Supports(s.provider, name) {
+			market.Providers = append(market.Providers, s.provider.Name())
+		}
+		markets = append(markets, market)
+	}
+	return markets
+}
+
+// Markets возвращает имена включенных рынков
+func (s *RatesService) Markets() []string {
+	return s.markets
+}
+
+// resolveMarket проверяет, что рынок включен; пустой рынок заменяется рынком по умолчанию
+func (s *RatesService) resolveMarket(market string) (string, error) {
+	if market == "" {
+		return s.markets[0], nil
+	}
+	for _, m := range s.markets {
+		if m == market {
+			return market, nil
+		}
+	}
+	return "", fmt.Errorf("%w: market %q is not enabled", models.ErrInvalidArgument, market)
+}
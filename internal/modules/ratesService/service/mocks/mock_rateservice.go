@@ -8,6 +8,7 @@ import (
 	context "context"
 	models "getUSDT/internal/models"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -35,19 +36,64 @@ func (m *MockRatesStorage) EXPECT() *MockRatesStorageMockRecorder {
 	return m.recorder
 }
 
-// GetRatesFromAPI mocks base method.
-func (m *MockRatesStorage) GetRatesFromAPI(ctx context.Context) (*models.Rate, error) {
+// GetCandles mocks base method.
+func (m *MockRatesStorage) GetCandles(ctx context.Context, market string, interval time.Duration, from, to time.Time) ([]models.Candle, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRatesFromAPI", ctx)
+	ret := m.ctrl.Call(m, "GetCandles", ctx, market, interval, from, to)
+	ret0, _ := ret[0].([]models.Candle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCandles indicates an expected call of GetCandles.
+func (mr *MockRatesStorageMockRecorder) GetCandles(ctx, market, interval, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCandles", reflect.TypeOf((*MockRatesStorage)(nil).GetCandles), ctx, market, interval, from, to)
+}
+
+// GetLatestRate mocks base method.
+func (m *MockRatesStorage) GetLatestRate(ctx context.Context, market string) (*models.Rate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestRate", ctx, market)
 	ret0, _ := ret[0].(*models.Rate)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetRatesFromAPI indicates an expected call of GetRatesFromAPI.
-func (mr *MockRatesStorageMockRecorder) GetRatesFromAPI(ctx interface{}) *gomock.Call {
+// GetLatestRate indicates an expected call of GetLatestRate.
+func (mr *MockRatesStorageMockRecorder) GetLatestRate(ctx, market interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestRate", reflect.TypeOf((*MockRatesStorage)(nil).GetLatestRate), ctx, market)
+}
+
+// GetRateBefore mocks base method.
+func (m *MockRatesStorage) GetRateBefore(ctx context.Context, market string, before time.Time) (*models.Rate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRateBefore", ctx, market, before)
+	ret0, _ := ret[0].(*models.Rate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRateBefore indicates an expected call of GetRateBefore.
+func (mr *MockRatesStorageMockRecorder) GetRateBefore(ctx, market, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRateBefore", reflect.TypeOf((*MockRatesStorage)(nil).GetRateBefore), ctx, market, before)
+}
+
+// GetRateHistory mocks base method.
+func (m *MockRatesStorage) GetRateHistory(ctx context.Context, filter models.RateHistoryFilter) ([]models.Rate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRateHistory", ctx, filter)
+	ret0, _ := ret[0].([]models.Rate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRateHistory indicates an expected call of GetRateHistory.
+func (mr *MockRatesStorageMockRecorder) GetRateHistory(ctx, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRatesFromAPI", reflect.TypeOf((*MockRatesStorage)(nil).GetRatesFromAPI), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRateHistory", reflect.TypeOf((*MockRatesStorage)(nil).GetRateHistory), ctx, filter)
 }
 
 // SaveRate mocks base method.
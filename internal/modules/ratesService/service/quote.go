@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"getUSDT/internal/models"
+	"getUSDT/internal/modules/ratesService/provider"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// bpsPerUnit количество базисных пунктов в единице
+var bpsPerUnit = decimal.NewFromInt(10000)
+
+// GetQuote рассчитывает средневзвешенную цену исполнения заданного объема по текущему стакану
+func (s *RatesService) GetQuote(ctx context.Context, market, side string, amount decimal.Decimal) (*models.Quote, error) {
+	// Создаем трассировщик для отслеживания выполнения этой операции
+	tracer := otel.Tracer("getUSDT.service")
+	ctx, span := tracer.Start(ctx, "GetQuote")
+	defer span.End()
+
+	// Проверяем параметры запроса
+	market, err := s.resolveMarket(market)
+	if err != nil {
+		return nil, err
+	}
+	if side != models.QuoteSideBuy && side != models.QuoteSideSell {
+		return nil, fmt.Errorf("%w: unsupported quote side %q", models.ErrInvalidArgument, side)
+	}
+	if !amount.IsPositive() {
+		return nil, fmt.Errorf("%w: amount must be positive", models.ErrInvalidArgument)
+	}
+
+	span.SetAttributes(
+		attribute.String("quote.market", market),
+		attribute.String("quote.side", side),
+		attribute.String("quote.amount", amount.String()),
+	)
+
+	// Для котировки нужен полный стакан, поэтому запрашиваем его у провайдера
+	book, err := s.fetchDepth(ctx, market)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to fetch order book")
+		return nil, err
+	}
+
+	quote, err := computeQuote(book, side, amount)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to compute quote")
+		return nil, err
+	}
+	quote.Market = market
+	quote.Timestamp = time.Now()
+
+	span.SetAttributes(
+		attribute.String("quote.average_price", quote.AveragePrice.String()),
+		attribute.String("quote.slippage_bps", quote.SlippageBps.String()),
+		attribute.Bool("quote.enough_liquidity", quote.EnoughLiquidity),
+	)
+	span.SetStatus(codes.Ok, "Quote computed successfully")
+	return quote, nil
+}
+
+// fetchDepth запрашивает стакан с реальной глубиной у первого доступного провайдера рынка
+func (s *RatesService) fetchDepth(ctx context.Context, market string) (*models.OrderBook, error) {
+	providers := provider.DepthProviders(s.provider, market)
+	if len(providers) == 0 {
+		return nil, fmt.Errorf("%w: no provider with order book depth for market %s", models.ErrOrderBookUnavailable, market)
+	}
+
+	var errs []error
+	for _, p := range providers {
+		book, err := p.FetchOrderBook(ctx, market)
+		if err == nil {
+			return book, nil
+		}
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("failed to fetch order book: %w", ctx.Err())
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", p.Name(), err))
+	}
+	return nil, fmt.Errorf("%w: failed to fetch order book: %w", models.ErrProviderUnavailable, errors.Join(errs...))
+}
+
+// computeQuote проходит по уровням стакана, пока не наберет нужный объем.
+// Покупка исполняется по заявкам на продажу, продажа — по заявкам на покупку.
+// Пустая сторона стакана и некорректные уровни означают, что биржа сейчас не дает пригодного стакана,
+// поэтому такие ошибки оборачиваются в ErrProviderUnavailable.
+func computeQuote(book *models.OrderBook, side string, amount decimal.Decimal) (*models.Quote, error) {
+	levels := book.Asks
+	if side == models.QuoteSideSell {
+		levels = book.Bids
+	}
+	if len(levels) == 0 {
+		return nil, fmt.Errorf("%w: no %s levels available in order book", models.ErrProviderUnavailable, side)
+	}
+
+	quote := &models.Quote{
+		Source: book.Source,
+		Side:   side,
+		Amount: amount,
+	}
+
+	remaining := amount
+	for i, level := range levels {
+		price, err := decimal.NewFromString(level.Price)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to parse price of level %d: %w", models.ErrProviderUnavailable, i, err)
+		}
+		if !price.IsPositive() {
+			return nil, fmt.Errorf("%w: non-positive price of level %d", models.ErrProviderUnavailable, i)
+		}
+		volume, err := decimal.NewFromString(level.Volume)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to parse volume of level %d: %w", models.ErrProviderUnavailable, i, err)
+		}
+		// Нулевой или отрицательный объем исказил бы среднюю цену и исполненный объем
+		if !volume.IsPositive() {
+			return nil, fmt.Errorf("%w: non-positive volume of level %d", models.ErrProviderUnavailable, i)
+		}
+		if i == 0 {
+			quote.TopPrice = price
+		}
+
+		take := decimal.Min(remaining, volume)
+		quote.Filled = quote.Filled.Add(take)
+		quote.Cost = quote.Cost.Add(take.Mul(price))
+		quote.LevelsUsed = i + 1
+
+		remaining = remaining.Sub(take)
+		if !remaining.IsPositive() {
+			break
+		}
+	}
+
+	quote.EnoughLiquidity = !remaining.IsPositive()
+	if quote.Filled.IsPositive() {
+		quote.AveragePrice = quote.Cost.Div(quote.Filled)
+	}
+
+	// Проскальзывание положительно, когда средняя цена хуже лучшей цены стакана
+	if quote.TopPrice.IsPositive() && quote.Filled.IsPositive() {
+		diff := quote.AveragePrice.Sub(quote.TopPrice)
+		if side == models.QuoteSideSell {
+			diff = diff.Neg()
+		}
+		quote.SlippageBps = diff.Div(quote.TopPrice).Mul(bpsPerUnit)
+	}
+
+	return quote, nil
+}
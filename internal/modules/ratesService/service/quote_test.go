@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"getUSDT/config"
+	"getUSDT/internal/models"
+	"getUSDT/internal/modules/ratesService/provider"
+	providermocks "getUSDT/internal/modules/ratesService/provider/mocks"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeQuote_WalksLevels(t *testing.T) {
+	book := &models.OrderBook{
+		Source: "garantex",
+		Asks: []models.OrderBookLevel{
+			{Price: "100", Volume: "100"},
+			{Price: "101", Volume: "100"},
+			{Price: "105", Volume: "100"},
+		},
+	}
+
+	// Покупка 150 задевает два уровня: 100 по 100 и 50 по 101
+	quote, err := computeQuote(book, models.QuoteSideBuy, dec("150"))
+
+	assert.NoError(t, err)
+	assert.True(t, quote.EnoughLiquidity)
+	assert.Equal(t, 2, quote.LevelsUsed)
+	assert.Equal(t, "150", quote.Filled.String())
+	assert.Equal(t, "15050", quote.Cost.String())
+	assert.Equal(t, "100.3333", quote.AveragePrice.Round(4).String())
+	assert.Equal(t, "100", quote.TopPrice.String())
+	assert.Equal(t, "33.33", quote.SlippageBps.Round(2).String())
+}
+
+func TestComputeQuote_NotEnoughLiquidity(t *testing.T) {
+	book := &models.OrderBook{
+		Bids: []models.OrderBookLevel{
+			{Price: "99", Volume: "100"},
+			{Price: "98", Volume: "100"},
+		},
+	}
+
+	// Продажа 300 исполняется только на объем стакана
+	quote, err := computeQuote(book, models.QuoteSideSell, dec("300"))
+
+	assert.NoError(t, err)
+	assert.False(t, quote.EnoughLiquidity)
+	assert.Equal(t, "200", quote.Filled.String())
+	assert.Equal(t, "98.5", quote.AveragePrice.String())
+	assert.Equal(t, "50.51", quote.SlippageBps.Round(2).String())
+}
+
+func TestComputeQuote_InvalidBook(t *testing.T) {
+	tests := []struct {
+		name string
+		asks []models.OrderBookLevel
+	}{
+		{name: "empty side"},
+		{name: "zero volume", asks: []models.OrderBookLevel{{Price: "100", Volume: "0"}, {Price: "101", Volume: "100"}}},
+		{name: "negative volume", asks: []models.OrderBookLevel{{Price: "100", Volume: "100"}, {Price: "101", Volume: "-50"}}},
+		{name: "zero price", asks: []models.OrderBookLevel{{Price: "0", Volume: "100"}}},
+		{name: "malformed volume", asks: []models.OrderBookLevel{{Price: "100", Volume: "abc"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			book := &models.OrderBook{Asks: tt.asks, Bids: []models.OrderBookLevel{{Price: "99", Volume: "100"}}}
+
+			// Непригодный стакан дает повторяемую ошибку, а не искаженную котировку
+			_, err := computeQuote(book, models.QuoteSideBuy, dec("150"))
+
+			assert.ErrorIs(t, err, models.ErrProviderUnavailable)
+		})
+	}
+}
+
+func TestGetQuote_InvalidArguments(t *testing.T) {
+	service := NewRatesService(nil, nil, config.RatesConfig{}, nil)
+
+	_, err := service.GetQuote(context.Background(), "", "hold", dec("1"))
+	assert.ErrorIs(t, err, models.ErrInvalidArgument)
+
+	_, err = service.GetQuote(context.Background(), "", models.QuoteSideBuy, dec("0"))
+	assert.ErrorIs(t, err, models.ErrInvalidArgument)
+}
+
+// newMember создает провайдера-участника составного провайдера с указанными рынками
+func newMember(ctrl *gomock.Controller, name string, markets ...string) *providermocks.MockRateProvider {
+	m := providermocks.NewMockRateProvider(ctrl)
+	m.EXPECT().Name().Return(name).AnyTimes()
+	m.EXPECT().Markets().Return(markets).AnyTimes()
+	return m
+}
+
+func TestGetQuote_CompositeUsesMemberBook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Первый участник не торгует рынком, второй недоступен, третий возвращает полный стакан
+	other := newMember(ctrl, "binance", "btcrub")
+	down := newMember(ctrl, "bybit", "usdtrub")
+	down.EXPECT().FetchOrderBook(gomock.Any(), "usdtrub").Return(nil, errors.New("connection refused")).Times(1)
+	garantex := newMember(ctrl, "garantex", "usdtrub")
+	garantex.EXPECT().FetchOrderBook(gomock.Any(), "usdtrub").Return(&models.OrderBook{
+		Source: "garantex",
+		Market: "usdtrub",
+		Asks: []models.OrderBookLevel{
+			{Price: "100", Volume: "1"},
+			{Price: "102", Volume: "1"},
+		},
+	}, nil).Times(1)
+
+	composite, err := provider.NewComposite([]provider.RateProvider{other, down, garantex}, config.CompositeConfig{})
+	require.NoError(t, err)
+	service := NewRatesService(nil, composite, config.RatesConfig{}, nil)
+
+	quote, err := service.GetQuote(context.Background(), "usdtrub", models.QuoteSideBuy, dec("2"))
+
+	// Котировка рассчитана по реальной глубине биржи, а не по синтетическому стакану
+	require.NoError(t, err)
+	assert.Equal(t, "garantex", quote.Source)
+	assert.Equal(t, 2, quote.LevelsUsed)
+	assert.Equal(t, "101", quote.AveragePrice.String())
+}
+
+func TestGetQuote_NoDepthProvider(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	composite, err := provider.NewComposite([]provider.RateProvider{newMember(ctrl, "binance", "btcrub")}, config.CompositeConfig{})
+	require.NoError(t, err)
+	service := NewRatesService(nil, composite, config.RatesConfig{Markets: []string{"usdtrub"}}, nil)
+
+	_, err = service.GetQuote(context.Background(), "usdtrub", models.QuoteSideBuy, dec("1"))
+
+	assert.ErrorIs(t, err, models.ErrOrderBookUnavailable)
+}
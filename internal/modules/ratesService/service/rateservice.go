@@ -2,13 +2,17 @@ package service
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"getUSDT/config"
 	"getUSDT/internal/models"
-	"net/http"
-	"strconv"
+	"getUSDT/internal/modules/ratesService/provider"
+	"getUSDT/internal/monitoring"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/shopspring/decimal"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
@@ -17,112 +21,295 @@ import (
 
 //go:generate mockgen -source=rateservice.go -destination=mocks/mock_rateservice.go -package=mocks
 
+// defaultMarket рынок по умолчанию, если в конфигурации не указаны рынки
+const defaultMarket = "usdtrub"
+
+// Источники курса для GetRates
+const (
+	// SourceLive — курс запрашивается у провайдера при каждом вызове
+	SourceLive = "live"
+	// SourceStorage — курс берется из хранилища, пока он не устарел
+	SourceStorage = "storage"
+)
+
+const (
+	defaultMaxAge        = 30 * time.Second
+	defaultSnapshotDepth = 10
+)
+
 // RatesService структура для работы с курсами
 type RatesService struct {
-	storage RatesStorage
+	storage       RatesStorage
+	provider      provider.RateProvider
+	hub           *Hub
+	source        string
+	maxAge        time.Duration
+	markets       []string
+	depth         int
+	cache         *rateCache
+	flights       flights
+	validator     *validator
+	metrics       *monitoring.Metrics
+	lastFetch     atomic.Int64 // Время последнего успешного получения курса в наносекундах UNIX
+	sourceFetches sync.Map     // Время последнего успешного получения курса по биржам-источникам
 }
 
 // RatesStorage интерфейс для взаимодействия с хранилищем данных
 type RatesStorage interface {
-	GetRatesFromAPI(ctx context.Context) (*models.Rate, error)
 	SaveRate(ctx context.Context, rate *models.Rate) error
+	GetLatestRate(ctx context.Context, market string) (*models.Rate, error)
+	GetRateBefore(ctx context.Context, market string, before time.Time) (*models.Rate, error)
+	GetRateHistory(ctx context.Context, filter models.RateHistoryFilter) ([]models.Rate, error)
+	GetCandles(ctx context.Context, market string, interval time.Duration, from, to time.Time) ([]models.Candle, error)
 }
 
-// NewRatesService создает новый экземпляр RatesService
-func NewRatesService(storage RatesStorage) *RatesService {
-	return &RatesService{
-		storage: storage,
+// NewRatesService создает новый экземпляр RatesService.
+// metrics может быть nil, тогда метрики кэша не собираются.
+func NewRatesService(storage RatesStorage, rateProvider provider.RateProvider, cfg config.RatesConfig, metrics *monitoring.Metrics) *RatesService {
+	source := cfg.Source
+	if source == "" {
+		source = SourceLive
+	}
+	maxAge := cfg.MaxAge
+	if maxAge <= 0 {
+		maxAge = defaultMaxAge
+	}
+	markets := cfg.Markets
+	if len(markets) == 0 {
+		markets = []string{defaultMarket}
+	}
+	depth := cfg.SnapshotDepth
+	if depth <= 0 {
+		depth = defaultSnapshotDepth
 	}
-}
-
-// Структуры для парсинга ответа от Garantex API
-type AskBid struct {
-	Price  string `json:"price"`  // Цена на покупку/продажу
-	Volume string `json:"volume"` // Объем
-	Amount string `json:"amount"` // Сумма
-	Factor string `json:"factor"` // Коэффициент
-	Type   string `json:"type"`   // Тип
-}
 
-type ApiResponse struct {
-	Asks []AskBid `json:"asks"` // Список заявок на покупку
-	Bids []AskBid `json:"bids"` // Список заявок на продажу
+	return &RatesService{
+		storage:   storage,
+		provider:  rateProvider,
+		hub:       NewHub(),
+		source:    source,
+		maxAge:    maxAge,
+		markets:   markets,
+		depth:     depth,
+		cache:     newRateCache(cfg.CacheTTL),
+		validator: newValidator(cfg.Validation, maxAge),
+		metrics:   metrics,
+	}
 }
 
-// Получаем текущие курсы с биржи Garantex с трассировкой
-func (s *RatesService) GetRatesFromAPI(ctx context.Context) (*models.Rate, error) {
+// GetRates возвращает актуальный курс рынка с учетом настроенного источника.
+// Пустой рынок означает рынок по умолчанию.
+func (s *RatesService) GetRates(ctx context.Context, market string) (*models.Rate, error) {
 	// Создаем трассировщик для отслеживания выполнения этой операции
 	tracer := otel.Tracer("getUSDT.service")
-	_, span := tracer.Start(ctx, "GetRatesFromAPI")
+	ctx, span := tracer.Start(ctx, "GetRates")
 	defer span.End()
 
-	// URL для запроса к API
-	url := "https://garantex.org/api/v2/depth?market=usdtrub"
+	market, err := s.resolveMarket(market)
+	if err != nil {
+		return nil, err
+	}
+
 	span.SetAttributes(
-		attribute.String("http.method", "GET"), // Метод HTTP запроса
-		attribute.String("http.url", url),      // URL запроса
+		attribute.String("rate.source", s.source),
+		attribute.String("rate.market", market),
 	)
 
-	// Создаем HTTP клиент с таймаутом
-	client := &http.Client{Timeout: 10 * time.Second}
-	start := time.Now() // Засекаем время начала запроса
+	// Свежий курс из кэша отдается без обращения к хранилищу и бирже
+	if rate, ok := s.cache.Get(market); ok {
+		s.recordCache(market, true)
+		span.AddEvent("Rate served from cache")
+		span.SetStatus(codes.Ok, "Rate served from cache")
+		return s.withAge(rate), nil
+	}
+	s.recordCache(market, false)
 
-	// Отправляем запрос и логируем события
-	span.AddEvent("Sending HTTP request")
-	resp, err := client.Get(url)
+	// Одновременные вызовы по одному рынку разделяют один запрос к бирже и одну запись в хранилище.
+	// Общий запрос не отменяется вместе с контекстом первого из вызывающих,
+	// но отменяется, когда истекли дедлайны или ушли все ожидающие.
+	f, shared := s.join(ctx, market)
+	defer s.leave(market, f)
+	if shared {
+		span.AddEvent("Rate request shared with concurrent callers")
+	}
+
+	select {
+	case <-ctx.Done():
+		span.RecordError(ctx.Err())
+		span.SetStatus(codes.Error, "Request cancelled")
+		return nil, ctx.Err()
+	case <-f.done:
+		if f.err != nil {
+			span.RecordError(f.err)
+			span.SetStatus(codes.Error, "Failed to get rate")
+			return nil, f.err
+		}
+		rate := s.withAge(f.rate)
+		span.SetAttributes(
+			attribute.Int64("rate.age_ms", rate.Age.Milliseconds()),
+			attribute.Bool("rate.is_stale", rate.Stale),
+		)
+		span.SetStatus(codes.Ok, "Rate loaded")
+		return rate, nil
+	}
+}
+
+// loadRate возвращает курс из хранилища или запрашивает его у провайдера и сохраняет
+func (s *RatesService) loadRate(ctx context.Context, market string) (*models.Rate, error) {
+	span := trace.SpanFromContext(ctx)
+
+	// Устаревший сохраненный курс, который отдается, если биржа недоступна
+	var stale *models.Rate
+	if s.source == SourceStorage {
+		rate, err := s.storage.GetLatestRate(ctx, market)
+		switch {
+		case err == nil && time.Since(rate.Timestamp) <= s.maxAge:
+			// Сохраненный курс достаточно свежий
+			span.AddEvent("Rate served from storage")
+			span.SetStatus(codes.Ok, "Rate served from storage")
+			return rate, nil
+		case err == nil:
+			stale = rate
+			span.AddEvent("Stored rate is stale", trace.WithAttributes(
+				attribute.String("rate.timestamp", rate.Timestamp.Format(time.RFC3339)),
+			))
+		case errors.Is(err, models.ErrRateNotFound):
+			span.AddEvent("No stored rate found")
+		default:
+			span.RecordError(err)
+			span.SetStatus(codes.Error, "Failed to get latest rate")
+			return nil, fmt.Errorf("failed to get latest rate: %w", err)
+		}
+	}
+
+	// Запрашиваем курс у провайдера и сохраняем его.
+	// Уже прочитанный из хранилища курс используется для проверки, повторно хранилище не запрашивается.
+	var rate *models.Rate
+	var err error
+	if s.source == SourceStorage {
+		rate, err = s.fetchRate(ctx, s.provider, market, stale)
+	} else {
+		rate, err = s.GetRatesFromAPI(ctx, market)
+	}
+	if err != nil && stale != nil {
+		// Лучше отдать устаревший курс с пометкой, чем не отдать ничего
+		span.RecordError(err)
+		span.AddEvent("Serving stale rate from storage")
+		return stale, nil
+	}
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "Failed to fetch rate from API")
 		return nil, fmt.Errorf("failed to fetch rate from API: %w", err)
 	}
-	span.AddEvent("HTTP response received") // Ответ получен
-	duration := time.Since(start)           // Время ответа
-	span.SetAttributes(attribute.Float64("http.duration_ms", float64(duration.Milliseconds())))
-	defer resp.Body.Close()
+	if err := s.SaveRate(ctx, rate); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Failed to save rate")
+		return nil, err
+	}
 
-	// Проверяем статус ответа
-	if resp.StatusCode != http.StatusOK {
-		err := fmt.Errorf("API returned non-200 status code: %d", resp.StatusCode)
+	span.SetStatus(codes.Ok, "Rate fetched from API")
+	return rate, nil
+}
+
+// recordCache учитывает попадание или промах кэша в метриках
+func (s *RatesService) recordCache(market string, hit bool) {
+	if s.metrics == nil {
+		return
+	}
+	if hit {
+		s.metrics.RateCacheHits.WithLabelValues(market).Inc()
+		return
+	}
+	s.metrics.RateCacheMisses.WithLabelValues(market).Inc()
+}
+
+// Получаем текущие курсы рынка у провайдера с трассировкой
+func (s *RatesService) GetRatesFromAPI(ctx context.Context, market string) (*models.Rate, error) {
+	return s.fetchRate(ctx, s.provider, market, s.previousRate(ctx, market))
+}
+
+// fetchRate получает стакан у указанного провайдера и извлекает из него курс.
+// last — последний сохраненный курс для проверки изменения курса или nil.
+func (s *RatesService) fetchRate(ctx context.Context, p provider.RateProvider, market string, last *models.Rate) (*models.Rate, error) {
+	// Создаем трассировщик для отслеживания выполнения этой операции
+	tracer := otel.Tracer("getUSDT.service")
+	ctx, span := tracer.Start(ctx, "GetRatesFromAPI")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("rate.provider", p.Name()), // Имя провайдера
+		attribute.String("rate.market", market),     // Рынок
+	)
+
+	// Проверяем, что провайдер поддерживает рынок
+	if !provider.Supports(p, market) {
+		err := fmt.Errorf("provider %s does not support market %s", p.Name(), market)
 		span.RecordError(err)
-		span.SetStatus(codes.Error, "Non-200 status code")
+		span.SetStatus(codes.Error, "Market is not supported")
 		return nil, err
 	}
 
-	// Декодируем JSON ответ от API в структуру
-	var apiResponse ApiResponse
-	if err := json.NewDecoder(resp.Body).Decode(&apiResponse); err != nil {
+	// Получаем стакан у провайдера
+	book, err := p.FetchOrderBook(ctx, market)
+	if err != nil {
 		span.RecordError(err)
-		span.SetStatus(codes.Error, "Failed to decode API response")
-		return nil, fmt.Errorf("failed to decode API response: %w", err)
+		span.SetStatus(codes.Error, "Failed to fetch order book")
+		return nil, fmt.Errorf("%w: %w", models.ErrProviderUnavailable, err)
 	}
 
 	// Проверяем наличие цен на покупку и продажу
-	if len(apiResponse.Asks) == 0 || len(apiResponse.Bids) == 0 {
-		err := fmt.Errorf("no ask/bid prices available in API response")
+	if len(book.Asks) == 0 || len(book.Bids) == 0 {
+		err := fmt.Errorf("%w: no ask/bid prices available in API response", models.ErrProviderUnavailable)
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "No ask/bid prices available")
 		return nil, err
 	}
 
-	// Преобразуем цены из строкового формата в числа с плавающей запятой
-	askPrice, err := strconv.ParseFloat(apiResponse.Asks[0].Price, 64)
+	// Преобразуем цены из строкового формата в точные десятичные числа
+	askPrice, err := decimal.NewFromString(book.Asks[0].Price)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "Failed to parse ask price")
 		return nil, fmt.Errorf("failed to parse ask price: %w", err)
 	}
-	bidPrice, err := strconv.ParseFloat(apiResponse.Bids[0].Price, 64)
+	bidPrice, err := decimal.NewFromString(book.Bids[0].Price)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, "Failed to parse bid price")
 		return nil, fmt.Errorf("failed to parse bid price: %w", err)
 	}
 
+	// Составной провайдер сообщает список бирж, для остальных источник один
+	sources := book.Sources
+	if len(sources) == 0 {
+		sources = []string{book.Source}
+	}
+
 	// Создаем объект модели курса и добавляем информацию в трассировку
-	rate := &models.Rate{Ask: askPrice, Bid: bidPrice}
+	fetchedAt := time.Now().UTC()
+	rate := &models.Rate{
+		Market:       market,
+		Source:       book.Source,
+		Sources:      sources,
+		Ask:          askPrice,
+		Bid:          bidPrice,
+		Timestamp:    fetchedAt,
+		ExchangeTime: book.Timestamp,
+		OrderBook:    topOfBook(book, s.depth),
+	}
+
+	// Проверяем правдоподобие курса перед тем, как его принять
+	if err := s.validateRate(ctx, rate, book, last); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "Rate rejected by validation")
+		return nil, err
+	}
+	s.recordFetch(fetchedAt, sources)
 	span.SetAttributes(
-		attribute.Float64("rate.ask", askPrice), // Цена на покупку
-		attribute.Float64("rate.bid", bidPrice), // Цена на продажу
+		attribute.String("rate.source", book.Source),    // Провайдер, вернувший стакан
+		attribute.String("rate.ask", askPrice.String()), // Цена на покупку
+		attribute.String("rate.bid", bidPrice.String()), // Цена на продажу
 	)
 	span.SetStatus(codes.Ok, "Operation completed successfully")
 
@@ -138,8 +325,9 @@ func (s *RatesService) SaveRate(ctx context.Context, rate *models.Rate) error {
 
 	// Логируем попытку сохранения курса
 	span.AddEvent("Attempting to save rate", trace.WithAttributes(
-		attribute.Float64("rate.ask", rate.Ask), // Цена на покупку
-		attribute.Float64("rate.bid", rate.Bid), // Цена на продажу
+		attribute.String("rate.market", rate.Market),    // Рынок
+		attribute.String("rate.ask", rate.Ask.String()), // Цена на покупку
+		attribute.String("rate.bid", rate.Bid.String()), // Цена на продажу
 	))
 
 	// Сохраняем курс в хранилище
@@ -150,8 +338,34 @@ func (s *RatesService) SaveRate(ctx context.Context, rate *models.Rate) error {
 		return fmt.Errorf("failed to save rate: %w", err)
 	}
 
+	// Рассылаем сохраненный курс подписчикам
+	s.hub.Publish(rate)
+
 	// Логируем успешное сохранение курса
 	span.AddEvent("Rate saved successfully")
 	span.SetStatus(codes.Ok, "Rate saved successfully")
 	return nil
 }
+
+// SubscribeRates подписывает на поток сохраняемых курсов.
+// Возвращает канал с курсами и функцию отписки.
+func (s *RatesService) SubscribeRates() (<-chan *models.Rate, func()) {
+	return s.hub.Subscribe()
+}
+
+// Close завершает все активные подписки на курсы
+func (s *RatesService) Close() {
+	s.hub.Close()
+}
+
+// topOfBook возвращает копию стакана, ограниченную depth уровнями с каждой стороны
+func topOfBook(book *models.OrderBook, depth int) *models.OrderBook {
+	top := *book
+	if len(top.Asks) > depth {
+		top.Asks = top.Asks[:depth]
+	}
+	if len(top.Bids) > depth {
+		top.Bids = top.Bids[:depth]
+	}
+	return &top
+}
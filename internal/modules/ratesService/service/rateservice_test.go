@@ -3,14 +3,23 @@ package service
 import (
 	"context"
 	"errors"
+	"getUSDT/config"
 	"getUSDT/internal/models"
+	providermocks "getUSDT/internal/modules/ratesService/provider/mocks"
 	"getUSDT/internal/modules/ratesService/service/mocks"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 )
 
+// dec создает десятичное число из строки для тестов
+func dec(value string) decimal.Decimal {
+	return decimal.RequireFromString(value)
+}
+
 func TestSaveRate_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -18,13 +27,13 @@ func TestSaveRate_Success(t *testing.T) {
 	mockStorage := mocks.NewMockRatesStorage(ctrl)
 
 	// Создаем тестовый курс
-	rate := &models.Rate{Ask: 100.5, Bid: 99.5}
+	rate := &models.Rate{Ask: dec("100.5"), Bid: dec("99.5")}
 
 	// Задаем ожидаемое поведение для мок-метода SaveRate
 	mockStorage.EXPECT().SaveRate(gomock.Any(), rate).Return(nil).Times(1)
 
 	// Создаем экземпляр RatesService с мок-стореджем
-	service := NewRatesService(mockStorage)
+	service := NewRatesService(mockStorage, nil, config.RatesConfig{}, nil)
 
 	// Выполняем тестируемую функцию
 	err := service.SaveRate(context.Background(), rate)
@@ -40,13 +49,13 @@ func TestSaveRate_Failure(t *testing.T) {
 	mockStorage := mocks.NewMockRatesStorage(ctrl)
 
 	// Создаем тестовый курс
-	rate := &models.Rate{Ask: 100.5, Bid: 99.5}
+	rate := &models.Rate{Ask: dec("100.5"), Bid: dec("99.5")}
 
 	// Задаем ожидаемое поведение для мок-метода SaveRate, который вернет ошибку
 	mockStorage.EXPECT().SaveRate(gomock.Any(), rate).Return(errors.New("save error")).Times(1)
 
 	// Создаем экземпляр RatesService с мок-стореджем
-	service := NewRatesService(mockStorage)
+	service := NewRatesService(mockStorage, nil, config.RatesConfig{}, nil)
 
 	// Выполняем тестируемую функцию
 	err := service.SaveRate(context.Background(), rate)
@@ -54,3 +63,221 @@ func TestSaveRate_Failure(t *testing.T) {
 	// Проверяем результаты
 	assert.Error(t, err)
 }
+
+func TestGetRatesFromAPI_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProvider := providermocks.NewMockRateProvider(ctrl)
+
+	// Провайдер возвращает стакан с несколькими уровнями
+	mockProvider.EXPECT().Name().Return("garantex").AnyTimes()
+	mockProvider.EXPECT().Markets().Return([]string{"usdtrub"}).AnyTimes()
+	mockProvider.EXPECT().FetchOrderBook(gomock.Any(), "usdtrub").Return(&models.OrderBook{
+		Source: "garantex",
+		Market: "usdtrub",
+		Asks:   []models.OrderBookLevel{{Price: "100.5"}, {Price: "101"}},
+		Bids:   []models.OrderBookLevel{{Price: "99.5"}, {Price: "99"}},
+	}, nil).Times(1)
+
+	service := NewRatesService(nil, mockProvider, config.RatesConfig{SnapshotDepth: 1}, nil)
+
+	rate, err := service.GetRatesFromAPI(context.Background(), "usdtrub")
+
+	// Курс берется с вершины стакана
+	assert.NoError(t, err)
+	assert.Equal(t, "100.5", rate.Ask.String())
+	assert.Equal(t, "99.5", rate.Bid.String())
+
+	// К курсу прикладывается источник и снимок стакана заданной глубины
+	assert.Equal(t, "garantex", rate.Source)
+	assert.Equal(t, []models.OrderBookLevel{{Price: "100.5"}}, rate.OrderBook.Asks)
+	assert.Equal(t, []models.OrderBookLevel{{Price: "99.5"}}, rate.OrderBook.Bids)
+}
+
+func TestGetRatesFromAPI_EmptyBook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProvider := providermocks.NewMockRateProvider(ctrl)
+
+	// Провайдер возвращает пустой стакан
+	mockProvider.EXPECT().Name().Return("garantex").AnyTimes()
+	mockProvider.EXPECT().Markets().Return([]string{"usdtrub"}).AnyTimes()
+	mockProvider.EXPECT().FetchOrderBook(gomock.Any(), "usdtrub").Return(&models.OrderBook{
+		Source: "garantex",
+		Market: "usdtrub",
+	}, nil).Times(1)
+
+	service := NewRatesService(nil, mockProvider, config.RatesConfig{}, nil)
+
+	_, err := service.GetRatesFromAPI(context.Background(), "usdtrub")
+
+	assert.Error(t, err)
+}
+
+func TestGetRates_FromStorage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockRatesStorage(ctrl)
+	mockProvider := providermocks.NewMockRateProvider(ctrl)
+
+	// В хранилище есть свежий курс, обращения к провайдеру быть не должно
+	stored := &models.Rate{ID: 1, Market: "usdtrub", Ask: dec("100.5"), Bid: dec("99.5"), Timestamp: time.Now().Add(-time.Second)}
+	mockStorage.EXPECT().GetLatestRate(gomock.Any(), "usdtrub").Return(stored, nil).Times(1)
+
+	service := NewRatesService(mockStorage, mockProvider, config.RatesConfig{Source: SourceStorage, MaxAge: time.Minute}, nil)
+
+	rate, err := service.GetRates(context.Background(), "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, stored.ID, rate.ID)
+	assert.Equal(t, stored.Ask, rate.Ask)
+	assert.GreaterOrEqual(t, rate.Age, time.Second)
+	assert.False(t, rate.Stale)
+}
+
+func TestGetRates_StaleFallsBackToAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockRatesStorage(ctrl)
+	mockProvider := providermocks.NewMockRateProvider(ctrl)
+
+	// Сохраненный курс устарел, поэтому курс запрашивается у провайдера и сохраняется
+	stale := &models.Rate{ID: 1, Ask: dec("90"), Bid: dec("89"), Timestamp: time.Now().Add(-time.Hour)}
+	mockStorage.EXPECT().GetLatestRate(gomock.Any(), "usdtrub").Return(stale, nil).Times(1)
+	mockProvider.EXPECT().Name().Return("garantex").AnyTimes()
+	mockProvider.EXPECT().Markets().Return([]string{"usdtrub"}).AnyTimes()
+	mockProvider.EXPECT().FetchOrderBook(gomock.Any(), "usdtrub").Return(&models.OrderBook{
+		Asks: []models.OrderBookLevel{{Price: "100.5"}},
+		Bids: []models.OrderBookLevel{{Price: "99.5"}},
+	}, nil).Times(1)
+	mockStorage.EXPECT().SaveRate(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	service := NewRatesService(mockStorage, mockProvider, config.RatesConfig{Source: SourceStorage, MaxAge: time.Minute}, nil)
+
+	rate, err := service.GetRates(context.Background(), "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "100.5", rate.Ask.String())
+}
+
+func TestGetRates_ConcurrentCallsShareFetch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockRatesStorage(ctrl)
+	mockProvider := providermocks.NewMockRateProvider(ctrl)
+
+	// Биржа отвечает, только когда все вызовы уже ждут результата
+	release := make(chan struct{})
+	mockProvider.EXPECT().Name().Return("garantex").AnyTimes()
+	mockProvider.EXPECT().Markets().Return([]string{"usdtrub"}).AnyTimes()
+	mockProvider.EXPECT().FetchOrderBook(gomock.Any(), "usdtrub").DoAndReturn(
+		func(ctx context.Context, market string) (*models.OrderBook, error) {
+			<-release
+			return &models.OrderBook{
+				Asks: []models.OrderBookLevel{{Price: "100.5"}},
+				Bids: []models.OrderBookLevel{{Price: "99.5"}},
+			}, nil
+		}).Times(1)
+	mockStorage.EXPECT().SaveRate(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	service := NewRatesService(mockStorage, mockProvider, config.RatesConfig{}, nil)
+
+	const callers = 10
+	results := make(chan *models.Rate, callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			rate, err := service.GetRates(context.Background(), "")
+			assert.NoError(t, err)
+			results <- rate
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	// Все вызовы получают один и тот же курс
+	first := <-results
+	for i := 1; i < callers; i++ {
+		assert.Same(t, first.OrderBook, (<-results).OrderBook)
+	}
+}
+
+func TestGetRates_ServedFromCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockRatesStorage(ctrl)
+	mockProvider := providermocks.NewMockRateProvider(ctrl)
+
+	// Второй вызов обслуживается из кэша без обращения к бирже
+	mockProvider.EXPECT().Name().Return("garantex").AnyTimes()
+	mockProvider.EXPECT().Markets().Return([]string{"usdtrub"}).AnyTimes()
+	mockProvider.EXPECT().FetchOrderBook(gomock.Any(), "usdtrub").Return(&models.OrderBook{
+		Asks: []models.OrderBookLevel{{Price: "100.5"}},
+		Bids: []models.OrderBookLevel{{Price: "99.5"}},
+	}, nil).Times(1)
+	mockStorage.EXPECT().SaveRate(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	service := NewRatesService(mockStorage, mockProvider, config.RatesConfig{CacheTTL: time.Minute}, nil)
+
+	first, err := service.GetRates(context.Background(), "")
+	assert.NoError(t, err)
+
+	second, err := service.GetRates(context.Background(), "")
+
+	assert.NoError(t, err)
+	assert.Same(t, first.OrderBook, second.OrderBook)
+	assert.Equal(t, first.Timestamp, second.Timestamp)
+}
+
+func TestGetRates_UnknownMarket(t *testing.T) {
+	service := NewRatesService(nil, nil, config.RatesConfig{Markets: []string{"usdtrub", "btcrub"}}, nil)
+
+	// Рынок, не включенный в конфигурации, отклоняется без обращения к хранилищу
+	_, err := service.GetRates(context.Background(), "ethrub")
+
+	assert.ErrorIs(t, err, models.ErrInvalidArgument)
+}
+
+func TestListMarkets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProvider := providermocks.NewMockRateProvider(ctrl)
+	mockProvider.EXPECT().Name().Return("garantex").AnyTimes()
+	mockProvider.EXPECT().Markets().Return([]string{"usdtrub"}).AnyTimes()
+
+	service := NewRatesService(nil, mockProvider, config.RatesConfig{Markets: []string{"usdtrub", "btcrub"}}, nil)
+
+	assert.Equal(t, []models.Market{
+		{Name: "usdtrub", Providers: []string{"garantex"}},
+		{Name: "btcrub", Providers: []string{}},
+	}, service.ListMarkets())
+}
+
+func TestGetRatesFromAPI_ExactDecimal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockProvider := providermocks.NewMockRateProvider(ctrl)
+
+	// Цены с точностью, которую нельзя представить в float64
+	mockProvider.EXPECT().Name().Return("garantex").AnyTimes()
+	mockProvider.EXPECT().Markets().Return([]string{"usdtrub"}).AnyTimes()
+	mockProvider.EXPECT().FetchOrderBook(gomock.Any(), "usdtrub").Return(&models.OrderBook{
+		Asks: []models.OrderBookLevel{{Price: "97.123456789012345678"}},
+		Bids: []models.OrderBookLevel{{Price: "96.000000000000000001"}},
+	}, nil).Times(1)
+
+	service := NewRatesService(nil, mockProvider, config.RatesConfig{}, nil)
+
+	rate, err := service.GetRatesFromAPI(context.Background(), "usdtrub")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "97.123456789012345678", rate.Ask.String())
+	assert.Equal(t, "96.000000000000000001", rate.Bid.String())
+}
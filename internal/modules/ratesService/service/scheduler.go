@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"getUSDT/config"
+	"getUSDT/internal/modules/ratesService/provider"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const defaultPollInterval = 10 * time.Second
+
+// Scheduler периодически опрашивает провайдеров и сохраняет полученные курсы
+type Scheduler struct {
+	log       *zap.Logger
+	service   *RatesService
+	providers []provider.RateProvider
+	interval  time.Duration
+	jitter    time.Duration
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	ticks  map[string]time.Time // Время завершения последнего опроса каждого провайдера
+}
+
+// NewScheduler создает планировщик опроса провайдеров
+func NewScheduler(log *zap.Logger, service *RatesService, providers []provider.RateProvider, cfg config.SchedulerConfig) *Scheduler {
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	return &Scheduler{
+		log:       log,
+		service:   service,
+		providers: providers,
+		interval:  interval,
+		jitter:    cfg.Jitter,
+	}
+}
+
+// Start запускает опрос каждого провайдера в отдельной горутине
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Повторный запуск ничего не делает
+	if s.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	s.ticks = make(map[string]time.Time, len(s.providers))
+	for _, p := range s.providers {
+		s.ticks[p.Name()] = time.Now()
+	}
+	for _, p := range s.providers {
+		s.wg.Add(1)
+		go s.poll(ctx, p)
+	}
+}
+
+// Stop останавливает опрос и дожидается завершения всех горутин
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	cancel := s.cancel
+	s.cancel = nil
+	s.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	s.wg.Wait()
+}
+
+// poll опрашивает провайдера, пока не будет отменен контекст
+func (s *Scheduler) poll(ctx context.Context, p provider.RateProvider) {
+	defer s.wg.Done()
+
+	log := s.log.With(zap.String("provider", p.Name()))
+	log.Info("rates polling started", zap.Duration("interval", s.interval))
+
+	// Первый опрос выполняем сразу после запуска
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info("rates polling stopped")
+			return
+		case <-timer.C:
+			s.collect(ctx, log, p)
+			s.tick(p.Name())
+			timer.Reset(s.nextDelay())
+		}
+	}
+}
+
+// collect получает у провайдера курсы всех включенных рынков и сохраняет их
+func (s *Scheduler) collect(ctx context.Context, log *zap.Logger, p provider.RateProvider) {
+	for _, market := range s.service.Markets() {
+		if !provider.Supports(p, market) {
+			continue
+		}
+
+		rate, err := s.service.fetchRate(ctx, p, market, s.service.previousRate(ctx, market))
+		if err != nil {
+			log.Warn("failed to fetch rate", zap.String("market", market), zap.Error(err))
+			continue
+		}
+
+		if err := s.service.SaveRate(ctx, rate); err != nil {
+			log.Warn("failed to save rate", zap.String("market", market), zap.Error(err))
+		}
+	}
+}
+
+// tick отмечает завершение очередного опроса провайдера
+func (s *Scheduler) tick(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ticks != nil {
+		s.ticks[name] = time.Now()
+	}
+}
+
+// Check проверяет, что планировщик запущен и опрос ни одного провайдера не завис.
+// Опрос считается зависшим, если он не завершался дольше трех максимальных интервалов.
+func (s *Scheduler) Check(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel == nil {
+		return errors.New("scheduler is not running")
+	}
+
+	limit := 3 * (s.interval + s.jitter)
+	var stuck []string
+	for name, last := range s.ticks {
+		if time.Since(last) > limit {
+			stuck = append(stuck, name)
+		}
+	}
+	if len(stuck) > 0 {
+		sort.Strings(stuck)
+		return fmt.Errorf("polling is stuck for providers: %s", strings.Join(stuck, ", "))
+	}
+	return nil
+}
+
+// nextDelay возвращает интервал до следующего опроса со случайным смещением
+func (s *Scheduler) nextDelay() time.Duration {
+	if s.jitter <= 0 {
+		return s.interval
+	}
+	return s.interval + time.Duration(rand.Int63n(int64(s.jitter)))
+}
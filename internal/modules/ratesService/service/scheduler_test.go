@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"getUSDT/config"
+	"getUSDT/internal/models"
+	"getUSDT/internal/modules/ratesService/provider"
+	providermocks "getUSDT/internal/modules/ratesService/provider/mocks"
+	"getUSDT/internal/modules/ratesService/service/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestScheduler_PollsAndSaves(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockRatesStorage(ctrl)
+	mockProvider := providermocks.NewMockRateProvider(ctrl)
+
+	mockProvider.EXPECT().Name().Return("garantex").AnyTimes()
+	mockProvider.EXPECT().Markets().Return([]string{"usdtrub"}).AnyTimes()
+	mockProvider.EXPECT().FetchOrderBook(gomock.Any(), "usdtrub").Return(&models.OrderBook{
+		Asks: []models.OrderBookLevel{{Price: "100.5"}},
+		Bids: []models.OrderBookLevel{{Price: "99.5"}},
+	}, nil).MinTimes(2)
+
+	// Каждый полученный курс должен сохраняться в хранилище
+	saved := make(chan struct{}, 10)
+	mockStorage.EXPECT().SaveRate(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, rate *models.Rate) error {
+		assert.Equal(t, "100.5", rate.Ask.String())
+		saved <- struct{}{}
+		return nil
+	}).MinTimes(2)
+
+	service := NewRatesService(mockStorage, mockProvider, config.RatesConfig{}, nil)
+	scheduler := NewScheduler(zap.NewNop(), service, []provider.RateProvider{mockProvider}, config.SchedulerConfig{
+		Interval: 10 * time.Millisecond,
+		Jitter:   5 * time.Millisecond,
+	})
+
+	// До запуска планировщик считается неработающим
+	assert.Error(t, scheduler.Check(context.Background()))
+
+	scheduler.Start()
+	for i := 0; i < 2; i++ {
+		select {
+		case <-saved:
+		case <-time.After(time.Second):
+			t.Fatal("scheduler did not save rate in time")
+		}
+	}
+	assert.NoError(t, scheduler.Check(context.Background()))
+	assert.NoError(t, service.CheckLastFetch(context.Background(), time.Minute))
+
+	scheduler.Stop()
+	assert.Error(t, scheduler.Check(context.Background()))
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"getUSDT/internal/models"
+	"strings"
+	"time"
+)
+
+// withAge возвращает копию курса с возрастом и признаком устаревания на текущий момент.
+// Копия нужна, так как один и тот же курс может одновременно отдаваться нескольким вызовам.
+func (s *RatesService) withAge(rate *models.Rate) *models.Rate {
+	stamped := *rate
+	stamped.Age = time.Since(rate.Timestamp)
+	if stamped.Age < 0 {
+		stamped.Age = 0
+	}
+	stamped.Stale = stamped.Age > s.maxAge
+	return &stamped
+}
+
+// CheckFreshness проверяет, что последний сохраненный курс каждого рынка не старше maxAge.
+// Используется для проверки готовности сервиса.
+func (s *RatesService) CheckFreshness(ctx context.Context, maxAge time.Duration) error {
+	var stale []string
+	for _, market := range s.markets {
+		rate, err := s.storage.GetLatestRate(ctx, market)
+		switch {
+		case errors.Is(err, models.ErrRateNotFound):
+			stale = append(stale, market+": no rate")
+		case err != nil:
+			return fmt.Errorf("failed to get latest rate: %w", err)
+		default:
+			if age := time.Since(rate.Timestamp); age > maxAge {
+				stale = append(stale, fmt.Sprintf("%s: %s old", market, age.Round(time.Second)))
+			}
+		}
+	}
+
+	if len(stale) > 0 {
+		return fmt.Errorf("stale rates (max age %s): %s", maxAge, strings.Join(stale, ", "))
+	}
+	return nil
+}
+
+// recordFetch запоминает время последнего успешного получения курса, в том числе по каждой бирже-источнику
+func (s *RatesService) recordFetch(at time.Time, sources []string) {
+	s.lastFetch.Store(at.UnixNano())
+	for _, source := range sources {
+		s.sourceFetches.Store(source, at.UnixNano())
+	}
+}
+
+// CheckLastFetch проверяет, что курс успешно получен у биржи не позднее maxAge назад
+func (s *RatesService) CheckLastFetch(ctx context.Context, maxAge time.Duration) error {
+	last := s.lastFetch.Load()
+	if last == 0 {
+		return errors.New("no successful exchange fetch yet")
+	}
+	if age := time.Since(time.Unix(0, last)); age > maxAge {
+		return fmt.Errorf("last successful exchange fetch was %s ago", age.Round(time.Second))
+	}
+	return nil
+}
+
+// CheckSourceFetch проверяет, что курс успешно получен у указанной биржи не позднее maxAge назад.
+// Учитываются курсы, в которых биржа была источником, в том числе в составе составного провайдера.
+func (s *RatesService) CheckSourceFetch(ctx context.Context, source string, maxAge time.Duration) error {
+	last, ok := s.sourceFetches.Load(source)
+	if !ok {
+		return fmt.Errorf("no successful fetch from %s yet", source)
+	}
+	if age := time.Since(time.Unix(0, last.(int64))); age > maxAge {
+		return fmt.Errorf("last successful fetch from %s was %s ago", source, age.Round(time.Second))
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"getUSDT/config"
+	"getUSDT/internal/models"
+	providermocks "getUSDT/internal/modules/ratesService/provider/mocks"
+	"getUSDT/internal/modules/ratesService/service/mocks"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRates_ServesStaleRateWhenExchangeFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockRatesStorage(ctrl)
+	mockProvider := providermocks.NewMockRateProvider(ctrl)
+
+	// Сохраненный курс устарел, а биржа недоступна
+	stale := &models.Rate{ID: 1, Market: "usdtrub", Ask: dec("90"), Bid: dec("89"), Timestamp: time.Now().Add(-time.Hour)}
+	mockStorage.EXPECT().GetLatestRate(gomock.Any(), "usdtrub").Return(stale, nil).Times(1)
+	mockProvider.EXPECT().Name().Return("garantex").AnyTimes()
+	mockProvider.EXPECT().Markets().Return([]string{"usdtrub"}).AnyTimes()
+	mockProvider.EXPECT().FetchOrderBook(gomock.Any(), "usdtrub").Return(nil, errors.New("timeout")).Times(1)
+
+	service := NewRatesService(mockStorage, mockProvider, config.RatesConfig{Source: SourceStorage, MaxAge: time.Minute}, nil)
+
+	rate, err := service.GetRates(context.Background(), "")
+
+	// Отдается устаревший курс с пометкой
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rate.ID)
+	assert.True(t, rate.Stale)
+	assert.GreaterOrEqual(t, rate.Age, time.Hour)
+}
+
+func TestGetRatesFromAPI_StampsTimes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	exchangeTime := time.Date(2024, 11, 1, 12, 0, 0, 0, time.UTC)
+	mockProvider := providermocks.NewMockRateProvider(ctrl)
+	mockProvider.EXPECT().Name().Return("garantex").AnyTimes()
+	mockProvider.EXPECT().Markets().Return([]string{"usdtrub"}).AnyTimes()
+	mockProvider.EXPECT().FetchOrderBook(gomock.Any(), "usdtrub").Return(&models.OrderBook{
+		Asks:      []models.OrderBookLevel{{Price: "100.5"}},
+		Bids:      []models.OrderBookLevel{{Price: "99.5"}},
+		Timestamp: exchangeTime,
+	}, nil).Times(1)
+
+	service := NewRatesService(nil, mockProvider, config.RatesConfig{}, nil)
+
+	rate, err := service.GetRatesFromAPI(context.Background(), "usdtrub")
+
+	assert.NoError(t, err)
+	assert.Equal(t, exchangeTime, rate.ExchangeTime)
+	assert.WithinDuration(t, time.Now(), rate.Timestamp, time.Second)
+}
+
+func TestCheckFreshness(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockRatesStorage(ctrl)
+
+	// Курс usdtrub свежий, курс btcrub устарел
+	mockStorage.EXPECT().GetLatestRate(gomock.Any(), "usdtrub").
+		Return(&models.Rate{Timestamp: time.Now().Add(-time.Second)}, nil).Times(2)
+	mockStorage.EXPECT().GetLatestRate(gomock.Any(), "btcrub").
+		Return(&models.Rate{Timestamp: time.Now().Add(-time.Hour)}, nil).Times(1)
+
+	service := NewRatesService(mockStorage, nil, config.RatesConfig{Markets: []string{"usdtrub", "btcrub"}}, nil)
+
+	err := service.CheckFreshness(context.Background(), time.Minute)
+	assert.ErrorContains(t, err, "btcrub")
+
+	service = NewRatesService(mockStorage, nil, config.RatesConfig{Markets: []string{"usdtrub"}}, nil)
+	assert.NoError(t, service.CheckFreshness(context.Background(), time.Minute))
+}
+
+func TestCheckSourceFetch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Составной провайдер вернул курс по двум биржам
+	mockProvider := providermocks.NewMockRateProvider(ctrl)
+	mockProvider.EXPECT().Name().Return("composite").AnyTimes()
+	mockProvider.EXPECT().Markets().Return([]string{"usdtrub"}).AnyTimes()
+	mockProvider.EXPECT().FetchOrderBook(gomock.Any(), "usdtrub").Return(&models.OrderBook{
+		Source:  "composite",
+		Sources: []string{"garantex", "binance"},
+		Asks:    []models.OrderBookLevel{{Price: "100.5"}},
+		Bids:    []models.OrderBookLevel{{Price: "99.5"}},
+	}, nil).Times(1)
+
+	service := NewRatesService(nil, mockProvider, config.RatesConfig{}, nil)
+
+	assert.Error(t, service.CheckSourceFetch(context.Background(), "garantex", time.Minute))
+
+	_, err := service.GetRatesFromAPI(context.Background(), "usdtrub")
+	assert.NoError(t, err)
+
+	assert.NoError(t, service.CheckSourceFetch(context.Background(), "garantex", time.Minute))
+	assert.NoError(t, service.CheckSourceFetch(context.Background(), "binance", time.Minute))
+	assert.Error(t, service.CheckSourceFetch(context.Background(), "bybit", time.Minute))
+
+	// Курс с биржи устарел
+	time.Sleep(10 * time.Millisecond)
+	assert.Error(t, service.CheckSourceFetch(context.Background(), "garantex", time.Millisecond))
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"getUSDT/config"
+	"getUSDT/internal/models"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Действия при непройденной проверке курса
+const (
+	// ValidationReject — курс отклоняется
+	ValidationReject = "reject"
+	// ValidationFlag — курс принимается с пометкой
+	ValidationFlag = "flag"
+)
+
+// Причины, по которым курс не прошел проверку
+const (
+	ReasonNonPositivePrice = "non_positive_price"
+	ReasonCrossedBook      = "crossed_book"
+	ReasonMaxSpread        = "max_spread"
+	ReasonMaxMove          = "max_move"
+	ReasonMinVolume        = "min_volume"
+)
+
+var two = decimal.NewFromInt(2)
+
+// validator проверяет правдоподобие курса и стакана, из которого он получен
+type validator struct {
+	action       string
+	crossedBook  bool
+	maxSpread    decimal.Decimal
+	maxMove      decimal.Decimal
+	maxMoveAge   time.Duration // Курс старше не используется для сравнения
+	minTopVolume decimal.Decimal
+}
+
+// newValidator создает проверку курса по конфигурации.
+// maxAge используется как возраст курса для сравнения, если max_move_age не задан.
+func newValidator(cfg config.ValidationConfig, maxAge time.Duration) *validator {
+	action := cfg.Action
+	if action != ValidationFlag {
+		action = ValidationReject
+	}
+	maxMoveAge := cfg.MaxMoveAge
+	if maxMoveAge <= 0 {
+		maxMoveAge = maxAge
+	}
+
+	return &validator{
+		action:       action,
+		crossedBook:  cfg.CrossedBook,
+		maxSpread:    decimal.NewFromFloat(cfg.MaxSpread),
+		maxMove:      decimal.NewFromFloat(cfg.MaxMove),
+		maxMoveAge:   maxMoveAge,
+		minTopVolume: decimal.NewFromFloat(cfg.MinTopVolume),
+	}
+}
+
+// needsLastRate сообщает, нужен ли для проверки последний сохраненный курс
+func (v *validator) needsLastRate() bool {
+	return v.maxMove.IsPositive()
+}
+
+// check возвращает причины, по которым курс не прошел проверку.
+// last может быть nil, тогда изменение относительно предыдущего курса не проверяется.
+// Не проверяется оно и относительно курса старше maxMoveAge: после простоя настоящее движение рынка
+// иначе отклоняло бы все новые курсы, и сохраненный курс больше никогда бы не обновился.
+func (v *validator) check(rate *models.Rate, book *models.OrderBook, last *models.Rate) []string {
+	// Неположительная цена не может быть курсом ни при каких настройках
+	if !rate.Ask.IsPositive() || !rate.Bid.IsPositive() {
+		return []string{ReasonNonPositivePrice}
+	}
+
+	var reasons []string
+	if v.crossedBook && rate.Bid.GreaterThanOrEqual(rate.Ask) {
+		reasons = append(reasons, ReasonCrossedBook)
+	}
+
+	mid := rate.Ask.Add(rate.Bid).Div(two)
+	if v.maxSpread.IsPositive() && rate.Ask.Sub(rate.Bid).Div(mid).GreaterThan(v.maxSpread) {
+		reasons = append(reasons, ReasonMaxSpread)
+	}
+
+	if v.maxMove.IsPositive() && v.comparable(last) {
+		lastMid := last.Ask.Add(last.Bid).Div(two)
+		if mid.Sub(lastMid).Abs().Div(lastMid).GreaterThan(v.maxMove) {
+			reasons = append(reasons, ReasonMaxMove)
+		}
+	}
+
+	if v.minTopVolume.IsPositive() &&
+		(topVolume(book.Asks).LessThan(v.minTopVolume) || topVolume(book.Bids).LessThan(v.minTopVolume)) {
+		reasons = append(reasons, ReasonMinVolume)
+	}
+
+	return reasons
+}
+
+// comparable сообщает, можно ли сравнивать новый курс с предыдущим
+func (v *validator) comparable(last *models.Rate) bool {
+	return last != nil && last.Ask.IsPositive() && last.Bid.IsPositive() &&
+		time.Since(last.Timestamp) <= v.maxMoveAge
+}
+
+// topVolume возвращает объем лучшей заявки; отсутствующий или некорректный объем считается нулевым
+func topVolume(levels []models.OrderBookLevel) decimal.Decimal {
+	if len(levels) == 0 {
+		return decimal.Zero
+	}
+	volume, err := decimal.NewFromString(levels[0].Volume)
+	if err != nil {
+		return decimal.Zero
+	}
+	return volume
+}
+
+// previousRate возвращает последний сохраненный курс рынка, если он нужен для проверки, иначе nil
+func (s *RatesService) previousRate(ctx context.Context, market string) *models.Rate {
+	if !s.validator.needsLastRate() || s.storage == nil {
+		return nil
+	}
+
+	last, err := s.storage.GetLatestRate(ctx, market)
+	switch {
+	case err == nil:
+		return last
+	case errors.Is(err, models.ErrRateNotFound):
+		// Сравнивать не с чем
+	default:
+		// Недоступность хранилища не должна останавливать получение курсов
+		span := trace.SpanFromContext(ctx)
+		span.RecordError(err)
+		span.AddEvent("Skipping max move validation")
+	}
+	return nil
+}
+
+// validateRate проверяет курс перед тем, как он будет принят.
+// last — последний сохраненный курс или nil, если его нет.
+// В режиме reject непрошедший проверку курс отклоняется с ErrRateRejected,
+// в режиме flag он принимается, а причины записываются в rate.Flags.
+func (s *RatesService) validateRate(ctx context.Context, rate *models.Rate, book *models.OrderBook, last *models.Rate) error {
+	span := trace.SpanFromContext(ctx)
+
+	reasons := s.validator.check(rate, book, last)
+	if len(reasons) == 0 {
+		return nil
+	}
+
+	action := s.validator.action
+	if reasons[0] == ReasonNonPositivePrice {
+		action = ValidationReject
+	}
+	for _, reason := range reasons {
+		if s.metrics != nil {
+			s.metrics.RateValidations.WithLabelValues(rate.Market, reason, action).Inc()
+		}
+	}
+	span.AddEvent("Rate failed validation", trace.WithAttributes(
+		attribute.StringSlice("validation.reasons", reasons),
+		attribute.String("validation.action", action),
+	))
+
+	if action == ValidationReject {
+		return fmt.Errorf("%w: %s", models.ErrRateRejected, strings.Join(reasons, ", "))
+	}
+	rate.Flags = reasons
+	return nil
+}
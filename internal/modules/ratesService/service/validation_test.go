@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"getUSDT/config"
+	"getUSDT/internal/models"
+	providermocks "getUSDT/internal/modules/ratesService/provider/mocks"
+	"getUSDT/internal/modules/ratesService/service/mocks"
+	"getUSDT/internal/monitoring"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidator_Check(t *testing.T) {
+	v := newValidator(config.ValidationConfig{
+		CrossedBook:  true,
+		MaxSpread:    0.05,
+		MaxMove:      0.1,
+		MinTopVolume: 1,
+	}, time.Minute)
+	book := func(askVolume, bidVolume string) *models.OrderBook {
+		return &models.OrderBook{
+			Asks: []models.OrderBookLevel{{Price: "1", Volume: askVolume}},
+			Bids: []models.OrderBookLevel{{Price: "1", Volume: bidVolume}},
+		}
+	}
+	last := &models.Rate{Ask: dec("100.5"), Bid: dec("99.5"), Timestamp: time.Now()}
+	old := &models.Rate{Ask: dec("100.5"), Bid: dec("99.5"), Timestamp: time.Now().Add(-time.Hour)}
+
+	tests := []struct {
+		name    string
+		ask     string
+		bid     string
+		book    *models.OrderBook
+		last    *models.Rate
+		reasons []string
+	}{
+		{name: "valid", ask: "100.5", bid: "99.5", book: book("10", "10"), last: last},
+		{name: "zero price", ask: "0", bid: "99.5", book: book("10", "10"), reasons: []string{ReasonNonPositivePrice}},
+		{name: "crossed book", ask: "99", bid: "100", book: book("10", "10"), reasons: []string{ReasonCrossedBook}},
+		{name: "wide spread", ask: "110", bid: "90", book: book("10", "10"), reasons: []string{ReasonMaxSpread}},
+		{name: "jump from last rate", ask: "120.5", bid: "119.5", book: book("10", "10"), last: last, reasons: []string{ReasonMaxMove}},
+		{name: "no last rate", ask: "120.5", bid: "119.5", book: book("10", "10")},
+		{name: "jump from outdated rate", ask: "120.5", bid: "119.5", book: book("10", "10"), last: old},
+		{name: "thin top of book", ask: "100.5", bid: "99.5", book: book("0.5", ""), reasons: []string{ReasonMinVolume}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate := &models.Rate{Ask: dec(tt.ask), Bid: dec(tt.bid)}
+
+			assert.Equal(t, tt.reasons, v.check(rate, tt.book, tt.last))
+		})
+	}
+}
+
+// crossedProvider создает мок провайдера, возвращающего стакан с bid выше ask
+func crossedProvider(ctrl *gomock.Controller) *providermocks.MockRateProvider {
+	m := providermocks.NewMockRateProvider(ctrl)
+	m.EXPECT().Name().Return("garantex").AnyTimes()
+	m.EXPECT().Markets().Return([]string{"usdtrub"}).AnyTimes()
+	m.EXPECT().FetchOrderBook(gomock.Any(), "usdtrub").Return(&models.OrderBook{
+		Source: "garantex",
+		Asks:   []models.OrderBookLevel{{Price: "99"}},
+		Bids:   []models.OrderBookLevel{{Price: "100"}},
+	}, nil).Times(1)
+	return m
+}
+
+func TestGetRatesFromAPI_RejectsInvalidRate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	metrics := monitoring.NewMetricsWithRegistry(prometheus.NewRegistry())
+	service := NewRatesService(nil, crossedProvider(ctrl), config.RatesConfig{
+		Validation: config.ValidationConfig{Action: ValidationReject, CrossedBook: true},
+	}, metrics)
+
+	_, err := service.GetRatesFromAPI(context.Background(), "usdtrub")
+
+	assert.ErrorIs(t, err, models.ErrRateRejected)
+
+	// Отказ учтен с причиной и действием
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.RateValidations.WithLabelValues("usdtrub", ReasonCrossedBook, ValidationReject)))
+	assert.Equal(t, 1, testutil.CollectAndCount(metrics.RateValidations))
+}
+
+func TestGetRatesFromAPI_FlagsInvalidRate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	metrics := monitoring.NewMetricsWithRegistry(prometheus.NewRegistry())
+	service := NewRatesService(nil, crossedProvider(ctrl), config.RatesConfig{
+		Validation: config.ValidationConfig{Action: ValidationFlag, CrossedBook: true},
+	}, metrics)
+
+	rate, err := service.GetRatesFromAPI(context.Background(), "usdtrub")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{ReasonCrossedBook}, rate.Flags)
+
+	// Помеченный курс учтен с действием flag
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.RateValidations.WithLabelValues("usdtrub", ReasonCrossedBook, ValidationFlag)))
+	assert.Equal(t, 1, testutil.CollectAndCount(metrics.RateValidations))
+}
+
+func TestGetRatesFromAPI_RejectsJumpFromStoredRate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockRatesStorage(ctrl)
+	mockProvider := providermocks.NewMockRateProvider(ctrl)
+
+	// Курс вырос на 20% относительно сохраненного
+	mockStorage.EXPECT().GetLatestRate(gomock.Any(), "usdtrub").
+		Return(&models.Rate{Ask: dec("100.5"), Bid: dec("99.5"), Timestamp: time.Now()}, nil).Times(1)
+	mockProvider.EXPECT().Name().Return("garantex").AnyTimes()
+	mockProvider.EXPECT().Markets().Return([]string{"usdtrub"}).AnyTimes()
+	mockProvider.EXPECT().FetchOrderBook(gomock.Any(), "usdtrub").Return(&models.OrderBook{
+		Asks: []models.OrderBookLevel{{Price: "120.5"}},
+		Bids: []models.OrderBookLevel{{Price: "119.5"}},
+	}, nil).Times(1)
+
+	service := NewRatesService(mockStorage, mockProvider, config.RatesConfig{
+		Validation: config.ValidationConfig{MaxMove: 0.1},
+	}, nil)
+
+	_, err := service.GetRatesFromAPI(context.Background(), "usdtrub")
+
+	assert.ErrorIs(t, err, models.ErrRateRejected)
+	assert.Contains(t, err.Error(), ReasonMaxMove)
+}
+
+func TestGetRatesFromAPI_AcceptsMoveAfterDowntime(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockRatesStorage(ctrl)
+	mockProvider := providermocks.NewMockRateProvider(ctrl)
+
+	// Последний курс сохранен час назад, за это время рынок вырос на 20%
+	mockStorage.EXPECT().GetLatestRate(gomock.Any(), "usdtrub").
+		Return(&models.Rate{Ask: dec("100.5"), Bid: dec("99.5"), Timestamp: time.Now().Add(-time.Hour)}, nil).Times(1)
+	mockProvider.EXPECT().Name().Return("garantex").AnyTimes()
+	mockProvider.EXPECT().Markets().Return([]string{"usdtrub"}).AnyTimes()
+	mockProvider.EXPECT().FetchOrderBook(gomock.Any(), "usdtrub").Return(&models.OrderBook{
+		Asks: []models.OrderBookLevel{{Price: "120.5"}},
+		Bids: []models.OrderBookLevel{{Price: "119.5"}},
+	}, nil).Times(1)
+
+	service := NewRatesService(mockStorage, mockProvider, config.RatesConfig{
+		Validation: config.ValidationConfig{MaxMove: 0.1, MaxMoveAge: time.Minute},
+	}, nil)
+
+	rate, err := service.GetRatesFromAPI(context.Background(), "usdtrub")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "120.5", rate.Ask.String())
+}
+
+func TestLoadRate_ValidatesAgainstStoredRateOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStorage := mocks.NewMockRatesStorage(ctrl)
+	mockProvider := providermocks.NewMockRateProvider(ctrl)
+
+	// Сохраненный курс устарел для выдачи, но годится для проверки изменения курса
+	stored := &models.Rate{Market: "usdtrub", Ask: dec("100.5"), Bid: dec("99.5"), Timestamp: time.Now().Add(-time.Minute)}
+	mockStorage.EXPECT().GetLatestRate(gomock.Any(), "usdtrub").Return(stored, nil).Times(1)
+	mockProvider.EXPECT().Name().Return("garantex").AnyTimes()
+	mockProvider.EXPECT().Markets().Return([]string{"usdtrub"}).AnyTimes()
+	mockProvider.EXPECT().FetchOrderBook(gomock.Any(), "usdtrub").Return(&models.OrderBook{
+		Asks: []models.OrderBookLevel{{Price: "120.5"}},
+		Bids: []models.OrderBookLevel{{Price: "119.5"}},
+	}, nil).Times(1)
+
+	service := NewRatesService(mockStorage, mockProvider, config.RatesConfig{
+		Source:     SourceStorage,
+		MaxAge:     30 * time.Second,
+		Validation: config.ValidationConfig{MaxMove: 0.1, MaxMoveAge: 10 * time.Minute},
+	}, nil)
+
+	// Скачок отклонен, и вместо него отдается сохраненный курс
+	rate, err := service.loadRate(context.Background(), "usdtrub")
+
+	assert.NoError(t, err)
+	assert.Same(t, stored, rate)
+}
@@ -2,10 +2,15 @@ package storage
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"getUSDT/internal/models"
+	"time"
 
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
 )
 
 type RatesStorage struct {
@@ -22,17 +27,241 @@ func (s *RatesStorage) Close() error {
 	return s.db.Close()
 }
 
-// SaveRate сохраняет курс USDT (Ask, Bid, Timestamp) в базе данных
+// rateRow строка таблицы rates
+type rateRow struct {
+	models.Rate
+	Sources      pq.StringArray `db:"sources"`
+	Flags        pq.StringArray `db:"flags"`
+	ExchangeTime sql.NullTime   `db:"exchange_time"`
+}
+
+// toModel преобразует строку таблицы в модель курса
+func (r *rateRow) toModel() *models.Rate {
+	rate := r.Rate
+	rate.Sources = r.Sources
+	rate.Flags = r.Flags
+	rate.ExchangeTime = r.ExchangeTime.Time
+	return &rate
+}
+
+// SaveRate сохраняет курс USDT (Ask, Bid, Timestamp) и снимок стакана в базе данных
 func (s *RatesStorage) SaveRate(ctx context.Context, rate *models.Rate) error {
-	query := `INSERT INTO rates (ask, bid) VALUES ($1, $2)`
-	_, err := s.db.Exec(query, rate.Ask, rate.Bid)
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Время получения курса проставляет сервис, база подставляет текущее время, только если его нет
+	query := `
+		INSERT INTO rates (market, source, sources, flags, ask, bid, timestamp, exchange_time)
+		VALUES ($1, $2, $3, $4, $5, $6, COALESCE($7, NOW()), $8)
+		RETURNING id, timestamp`
+	err = tx.QueryRowxContext(ctx, query, rate.Market, rate.Source, pq.Array(rate.Sources), pq.Array(rate.Flags),
+		rate.Ask, rate.Bid, nullTime(rate.Timestamp), nullTime(rate.ExchangeTime)).
+		Scan(&rate.ID, &rate.Timestamp)
 	if err != nil {
 		return fmt.Errorf("failed to execute insert query: %w", err)
 	}
+
+	if rate.OrderBook != nil {
+		if err := saveOrderBook(ctx, tx, rate.ID, rate.OrderBook); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// nullTime преобразует нулевое время в NULL
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+// saveOrderBook сохраняет уровни стакана, относящиеся к курсу.
+// Некорректные уровни пропускаются, чтобы ошибка в глубине стакана не мешала сохранить курс.
+func saveOrderBook(ctx context.Context, tx *sqlx.Tx, rateID int64, book *models.OrderBook) error {
+	query := `
+		INSERT INTO order_book_levels (rate_id, source, market, side, level, price, volume, amount)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, '')::NUMERIC, NULLIF($8, '')::NUMERIC)`
+	stmt, err := tx.PreparexContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to prepare order book insert: %w", err)
+	}
+	defer stmt.Close()
+
+	sides := []struct {
+		name   string
+		levels []models.OrderBookLevel
+	}{
+		{name: models.SideAsk, levels: book.Asks},
+		{name: models.SideBid, levels: book.Bids},
+	}
+	for _, side := range sides {
+		for i, level := range side.levels {
+			level, ok := sanitizeLevel(level)
+			if !ok {
+				continue
+			}
+			_, err := stmt.ExecContext(ctx, rateID, book.Source, book.Market, side.name, i, level.Price, level.Volume, level.Amount)
+			if err != nil {
+				return fmt.Errorf("failed to insert order book level: %w", err)
+			}
+		}
+	}
 	return nil
 }
 
-// GetRatesFromAPI заглушка для обеспечения интерфейсной совместимости
-func (s *RatesStorage) GetRatesFromAPI(ctx context.Context) (*models.Rate, error) {
-	return nil, fmt.Errorf("GetRatesFromAPI is not implemented")
+// GetLatestRate возвращает последний сохраненный курс рынка
+func (s *RatesStorage) GetLatestRate(ctx context.Context, market string) (*models.Rate, error) {
+	query := `
+		SELECT id, market, source, sources, flags, ask, bid, timestamp, exchange_time
+		FROM rates
+		WHERE market = $1
+		ORDER BY timestamp DESC, id DESC
+		LIMIT 1`
+	var row rateRow
+	if err := s.db.GetContext(ctx, &row, query, market); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, models.ErrRateNotFound
+		}
+		return nil, fmt.Errorf("failed to select latest rate: %w", err)
+	}
+	return row.toModel(), nil
+}
+
+// GetRateBefore возвращает последний курс рынка, сохраненный строго раньше before
+func (s *RatesStorage) GetRateBefore(ctx context.Context, market string, before time.Time) (*models.Rate, error) {
+	query := `
+		SELECT id, market, source, sources, flags, ask, bid, timestamp, exchange_time
+		FROM rates
+		WHERE market = $1 AND timestamp < $2
+		ORDER BY timestamp DESC, id DESC
+		LIMIT 1`
+	var row rateRow
+	if err := s.db.GetContext(ctx, &row, query, market, before); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, models.ErrRateNotFound
+		}
+		return nil, fmt.Errorf("failed to select rate before %s: %w", before.Format(time.RFC3339), err)
+	}
+	return row.toModel(), nil
+}
+
+// GetRateHistory возвращает курсы за период в порядке возрастания времени
+func (s *RatesStorage) GetRateHistory(ctx context.Context, filter models.RateHistoryFilter) ([]models.Rate, error) {
+	// Без курсора начинаем с начала периода; ID записей начинаются с 1
+	after := models.RateCursor{Timestamp: filter.From}
+	if filter.After != nil {
+		after = *filter.After
+	}
+
+	query := `
+		SELECT id, market, source, sources, flags, ask, bid, timestamp, exchange_time
+		FROM rates
+		WHERE market = $1 AND timestamp >= $2 AND (timestamp, id) > ($3, $4) AND timestamp < $5
+		ORDER BY timestamp, id
+		LIMIT $6`
+	var rows []rateRow
+	err := s.db.SelectContext(ctx, &rows, query,
+		filter.Market, filter.From, after.Timestamp, after.ID, filter.To, filter.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select rate history: %w", err)
+	}
+
+	rates := make([]models.Rate, 0, len(rows))
+	for _, row := range rows {
+		rates = append(rates, *row.toModel())
+	}
+	return rates, nil
+}
+
+// candleRow строка результата агрегации свечей
+type candleRow struct {
+	Bucket   time.Time       `db:"bucket"`
+	AskOpen  decimal.Decimal `db:"ask_open"`
+	AskHigh  decimal.Decimal `db:"ask_high"`
+	AskLow   decimal.Decimal `db:"ask_low"`
+	AskClose decimal.Decimal `db:"ask_close"`
+	BidOpen  decimal.Decimal `db:"bid_open"`
+	BidHigh  decimal.Decimal `db:"bid_high"`
+	BidLow   decimal.Decimal `db:"bid_low"`
+	BidClose decimal.Decimal `db:"bid_close"`
+	MidOpen  decimal.Decimal `db:"mid_open"`
+	MidHigh  decimal.Decimal `db:"mid_high"`
+	MidLow   decimal.Decimal `db:"mid_low"`
+	MidClose decimal.Decimal `db:"mid_close"`
+	Count    int64           `db:"count"`
+}
+
+// GetCandles агрегирует курсы рынка за период [from, to) в свечи указанного интервала.
+// Интервалы выравниваются по UNIX-эпохе, пустые интервалы в результат не попадают.
+func (s *RatesStorage) GetCandles(ctx context.Context, market string, interval time.Duration, from, to time.Time) ([]models.Candle, error) {
+	query := `
+		WITH bucketed AS (
+			SELECT
+				to_timestamp(floor(extract(epoch FROM timestamp) / $1) * $1) AS bucket,
+				id, timestamp, ask, bid, (ask + bid) / 2 AS mid
+			FROM rates
+			WHERE market = $2 AND timestamp >= $3 AND timestamp < $4
+		)
+		SELECT
+			bucket,
+			(array_agg(ask ORDER BY timestamp, id))[1] AS ask_open,
+			max(ask) AS ask_high,
+			min(ask) AS ask_low,
+			(array_agg(ask ORDER BY timestamp DESC, id DESC))[1] AS ask_close,
+			(array_agg(bid ORDER BY timestamp, id))[1] AS bid_open,
+			max(bid) AS bid_high,
+			min(bid) AS bid_low,
+			(array_agg(bid ORDER BY timestamp DESC, id DESC))[1] AS bid_close,
+			(array_agg(mid ORDER BY timestamp, id))[1] AS mid_open,
+			max(mid) AS mid_high,
+			min(mid) AS mid_low,
+			(array_agg(mid ORDER BY timestamp DESC, id DESC))[1] AS mid_close,
+			count(*) AS count
+		FROM bucketed
+		GROUP BY bucket
+		ORDER BY bucket`
+	var rows []candleRow
+	if err := s.db.SelectContext(ctx, &rows, query, interval.Seconds(), market, from, to); err != nil {
+		return nil, fmt.Errorf("failed to aggregate candles: %w", err)
+	}
+
+	candles := make([]models.Candle, 0, len(rows))
+	for _, row := range rows {
+		candles = append(candles, models.Candle{
+			Start: row.Bucket,
+			Ask:   models.OHLC{Open: row.AskOpen, High: row.AskHigh, Low: row.AskLow, Close: row.AskClose},
+			Bid:   models.OHLC{Open: row.BidOpen, High: row.BidHigh, Low: row.BidLow, Close: row.BidClose},
+			Mid:   models.OHLC{Open: row.MidOpen, High: row.MidHigh, Low: row.MidLow, Close: row.MidClose},
+			Count: row.Count,
+		})
+	}
+	return candles, nil
+}
+
+// sanitizeLevel проверяет уровень стакана перед сохранением.
+// Уровень без положительной цены пропускается, некорректные объем и сумма сохраняются как NULL.
+func sanitizeLevel(level models.OrderBookLevel) (models.OrderBookLevel, bool) {
+	price, err := decimal.NewFromString(level.Price)
+	if err != nil || !price.IsPositive() {
+		return level, false
+	}
+	level.Price = price.String()
+	level.Volume = numericOrEmpty(level.Volume)
+	level.Amount = numericOrEmpty(level.Amount)
+	return level, true
+}
+
+// numericOrEmpty возвращает число в каноническом виде или пустую строку, если значение не число
+func numericOrEmpty(value string) string {
+	d, err := decimal.NewFromString(value)
+	if err != nil {
+		return ""
+	}
+	return d.String()
 }
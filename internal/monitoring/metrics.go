@@ -6,12 +6,21 @@ import (
 
 // Metrics — структура для хранения всех метрик
 type Metrics struct {
-	RequestsTotal   prometheus.Counter
-	RequestsLatency prometheus.Histogram
+	RequestsTotal     prometheus.Counter
+	RequestsLatency   prometheus.Histogram
+	ProviderFailovers *prometheus.CounterVec
+	RateCacheHits     *prometheus.CounterVec
+	RateCacheMisses   *prometheus.CounterVec
+	RateValidations   *prometheus.CounterVec
 }
 
-// NewMetrics создает новую структуру метрик
+// NewMetrics создает новую структуру метрик и регистрирует их в реестре Prometheus по умолчанию
 func NewMetrics() *Metrics {
+	return NewMetricsWithRegistry(prometheus.DefaultRegisterer)
+}
+
+// NewMetricsWithRegistry создает новую структуру метрик и регистрирует их в указанном реестре
+func NewMetricsWithRegistry(reg prometheus.Registerer) *Metrics {
 	m := &Metrics{
 		RequestsTotal: prometheus.NewCounter(
 			prometheus.CounterOpts{
@@ -24,8 +33,28 @@ func NewMetrics() *Metrics {
 				Help:    "Histogram of gRPC request latencies",
 				Buckets: prometheus.DefBuckets,
 			}),
+		ProviderFailovers: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "rate_provider_failovers_total",
+				Help: "Total number of switches from a failed rate provider to the next one",
+			}, []string{"from", "to"}),
+		RateCacheHits: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "rates_cache_hits_total",
+				Help: "Total number of GetRates calls served from the in-process cache",
+			}, []string{"market"}),
+		RateCacheMisses: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "rates_cache_misses_total",
+				Help: "Total number of GetRates calls not found in the in-process cache",
+			}, []string{"market"}),
+		RateValidations: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "rate_validation_failures_total",
+				Help: "Total number of failed rate validation checks by reason and the action taken (reject or flag)",
+			}, []string{"market", "reason", "action"}),
 	}
 	// Регистрируем метрики
-	prometheus.MustRegister(m.RequestsTotal, m.RequestsLatency)
+	reg.MustRegister(m.RequestsTotal, m.RequestsLatency, m.ProviderFailovers, m.RateCacheHits, m.RateCacheMisses, m.RateValidations)
 	return m
 }
@@ -0,0 +1,183 @@
+// Package fakegarantex реализует поддельный HTTP сервер биржи Garantex для тестов.
+// Сервер отвечает на запросы /depth?market=... заранее заданными ответами.
+package fakegarantex
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+)
+
+// Response сценарий ответа сервера на один запрос стакана
+type Response struct {
+	Status int           // HTTP статус, 0 — 200
+	Body   string        // Тело ответа
+	Delay  time.Duration // Задержка перед ответом
+}
+
+// Level уровень стакана в ответе биржи
+type Level struct {
+	Price  string `json:"price"`
+	Volume string `json:"volume"`
+	Amount string `json:"amount"`
+	Factor string `json:"factor"`
+	Type   string `json:"type"`
+}
+
+// Server поддельный сервер Garantex.
+// Для каждого рынка ответы берутся из очереди, а когда она пуста — повторяется последний ответ.
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	queues    map[string][]Response
+	last      map[string]Response
+	requests  map[string]int
+	cancelled map[string]int
+}
+
+// New запускает поддельный сервер. Сервер нужно остановить вызовом Close.
+func New() *Server {
+	s := &Server{
+		queues:    make(map[string][]Response),
+		last:      make(map[string]Response),
+		requests:  make(map[string]int),
+		cancelled: make(map[string]int),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/depth", s.handleDepth)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// BaseURL возвращает базовый URL API для конфигурации провайдера
+func (s *Server) BaseURL() string {
+	return s.URL
+}
+
+// Enqueue добавляет ответы в очередь рынка
+func (s *Server) Enqueue(market string, responses ...Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.queues[market] = append(s.queues[market], responses...)
+}
+
+// Requests возвращает число запросов стакана рынка
+func (s *Server) Requests(market string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[market]
+}
+
+// Cancelled возвращает число запросов стакана рынка, отмененных клиентом до ответа
+func (s *Server) Cancelled(market string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cancelled[market]
+}
+
+// next возвращает очередной ответ для рынка
+func (s *Server) next(market string) (Response, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests[market]++
+	if queue := s.queues[market]; len(queue) > 0 {
+		s.last[market] = queue[0]
+		s.queues[market] = queue[1:]
+	}
+	resp, ok := s.last[market]
+	return resp, ok
+}
+
+func (s *Server) handleDepth(w http.ResponseWriter, r *http.Request) {
+	market := r.URL.Query().Get("market")
+	resp, ok := s.next(market)
+	if !ok {
+		http.Error(w, fmt.Sprintf(`{"error":"market %s not found"}`, market), http.StatusNotFound)
+		return
+	}
+
+	if resp.Delay > 0 {
+		timer := time.NewTimer(resp.Delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-r.Context().Done():
+			// Клиент отменил запрос, отвечать некому
+			s.mu.Lock()
+			s.cancelled[market]++
+			s.mu.Unlock()
+			return
+		}
+	}
+
+	status := resp.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(resp.Body))
+}
+
+// Book возвращает ответ со стаканом из указанных уровней
+func Book(asks, bids []Level) Response {
+	body, err := json.Marshal(struct {
+		Timestamp int64   `json:"timestamp"`
+		Asks      []Level `json:"asks"`
+		Bids      []Level `json:"bids"`
+	}{
+		Timestamp: time.Now().Unix(),
+		Asks:      asks,
+		Bids:      bids,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return Response{Body: string(body)}
+}
+
+// Prices возвращает ответ со стаканом из одного уровня на каждой стороне
+func Prices(ask, bid string) Response {
+	return Book(
+		[]Level{{Price: ask, Volume: "1000", Amount: "1000", Type: "limit"}},
+		[]Level{{Price: bid, Volume: "1000", Amount: "1000", Type: "limit"}},
+	)
+}
+
+// PriceSequence возвращает последовательность стаканов по парам цен ask, bid
+func PriceSequence(prices ...string) []Response {
+	if len(prices)%2 != 0 {
+		panic("fakegarantex: PriceSequence expects ask/bid pairs")
+	}
+	responses := make([]Response, 0, len(prices)/2)
+	for i := 0; i < len(prices); i += 2 {
+		responses = append(responses, Prices(prices[i], prices[i+1]))
+	}
+	return responses
+}
+
+// EmptyBook возвращает ответ с пустым стаканом
+func EmptyBook() Response {
+	return Book(nil, nil)
+}
+
+// Malformed возвращает ответ с некорректным JSON
+func Malformed() Response {
+	return Response{Body: `{"asks": [{"price": "100.5"`}
+}
+
+// Status возвращает ответ с указанным HTTP статусом
+func Status(code int) Response {
+	return Response{Status: code, Body: fmt.Sprintf(`{"error":"%s"}`, http.StatusText(code))}
+}
+
+// Slow возвращает ответ, отправляемый с задержкой
+func Slow(delay time.Duration, resp Response) Response {
+	resp.Delay = delay
+	return resp
+}
@@ -20,11 +20,62 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type GetQuoteRequest_Side int32
+
+const (
+	GetQuoteRequest_UNSPECIFIED GetQuoteRequest_Side = 0 // Направление не указано
+	GetQuoteRequest_BUY         GetQuoteRequest_Side = 1 // Покупка базовой валюты по заявкам на продажу
+	GetQuoteRequest_SELL        GetQuoteRequest_Side = 2 // Продажа базовой валюты по заявкам на покупку
+)
+
+// Enum value maps for GetQuoteRequest_Side.
+var (
+	GetQuoteRequest_Side_name = map[int32]string{
+		0: "UNSPECIFIED",
+		1: "BUY",
+		2: "SELL",
+	}
+	GetQuoteRequest_Side_value = map[string]int32{
+		"UNSPECIFIED": 0,
+		"BUY":         1,
+		"SELL":        2,
+	}
+)
+
+func (x GetQuoteRequest_Side) Enum() *GetQuoteRequest_Side {
+	p := new(GetQuoteRequest_Side)
+	*p = x
+	return p
+}
+
+func (x GetQuoteRequest_Side) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GetQuoteRequest_Side) Descriptor() protoreflect.EnumDescriptor {
+	return file_usdt_proto_enumTypes[0].Descriptor()
+}
+
+func (GetQuoteRequest_Side) Type() protoreflect.EnumType {
+	return &file_usdt_proto_enumTypes[0]
+}
+
+func (x GetQuoteRequest_Side) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GetQuoteRequest_Side.Descriptor instead.
+func (GetQuoteRequest_Side) EnumDescriptor() ([]byte, []int) {
+	return file_usdt_proto_rawDescGZIP(), []int{13, 0}
+}
+
 // Запрос для метода GetRates
 type GetRatesRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Market string `protobuf:"bytes,1,opt,name=market,proto3" json:"market,omitempty"` // Рынок, например usdtrub; пустой — рынок по умолчанию
 }
 
 func (x *GetRatesRequest) Reset() {
@@ -57,15 +108,31 @@ func (*GetRatesRequest) Descriptor() ([]byte, []int) {
 	return file_usdt_proto_rawDescGZIP(), []int{0}
 }
 
+func (x *GetRatesRequest) GetMarket() string {
+	if x != nil {
+		return x.Market
+	}
+	return ""
+}
+
 // Ответ для метода GetRates
 type GetRatesResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ask       float64 `protobuf:"fixed64,1,opt,name=ask,proto3" json:"ask,omitempty"`            // Первая цена ask
-	Bid       float64 `protobuf:"fixed64,2,opt,name=bid,proto3" json:"bid,omitempty"`            // Первая цена bid
-	Timestamp int64   `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Временная метка в UNIX формате
+	Ask               float64  `protobuf:"fixed64,1,opt,name=ask,proto3" json:"ask,omitempty"`                                                      // Первая цена ask
+	Bid               float64  `protobuf:"fixed64,2,opt,name=bid,proto3" json:"bid,omitempty"`                                                      // Первая цена bid
+	Timestamp         int64    `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                                           // Временная метка в UNIX формате
+	Market            string   `protobuf:"bytes,4,opt,name=market,proto3" json:"market,omitempty"`                                                  // Рынок курса
+	Source            string   `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"`                                                  // Провайдер, вернувший курс
+	AskExact          string   `protobuf:"bytes,6,opt,name=ask_exact,json=askExact,proto3" json:"ask_exact,omitempty"`                              // Первая цена ask в виде точной десятичной строки
+	BidExact          string   `protobuf:"bytes,7,opt,name=bid_exact,json=bidExact,proto3" json:"bid_exact,omitempty"`                              // Первая цена bid в виде точной десятичной строки
+	Sources           []string `protobuf:"bytes,8,rep,name=sources,proto3" json:"sources,omitempty"`                                                // Биржи, участвовавшие в расчете курса
+	Flags             []string `protobuf:"bytes,9,rep,name=flags,proto3" json:"flags,omitempty"`                                                    // Проверки, которые курс не прошел, если он принят с пометкой
+	ExchangeTimestamp int64    `protobuf:"varint,10,opt,name=exchange_timestamp,json=exchangeTimestamp,proto3" json:"exchange_timestamp,omitempty"` // Время стакана по часам биржи в UNIX формате, 0 — неизвестно
+	AgeMs             int64    `protobuf:"varint,11,opt,name=age_ms,json=ageMs,proto3" json:"age_ms,omitempty"`                                     // Возраст курса в миллисекундах на момент ответа
+	IsStale           bool     `protobuf:"varint,12,opt,name=is_stale,json=isStale,proto3" json:"is_stale,omitempty"`                               // Курс старше допустимого возраста, например при недоступности биржи
 }
 
 func (x *GetRatesResponse) Reset() {
@@ -119,24 +186,1160 @@ func (x *GetRatesResponse) GetTimestamp() int64 {
 	return 0
 }
 
+func (x *GetRatesResponse) GetMarket() string {
+	if x != nil {
+		return x.Market
+	}
+	return ""
+}
+
+func (x *GetRatesResponse) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *GetRatesResponse) GetAskExact() string {
+	if x != nil {
+		return x.AskExact
+	}
+	return ""
+}
+
+func (x *GetRatesResponse) GetBidExact() string {
+	if x != nil {
+		return x.BidExact
+	}
+	return ""
+}
+
+func (x *GetRatesResponse) GetSources() []string {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+func (x *GetRatesResponse) GetFlags() []string {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+func (x *GetRatesResponse) GetExchangeTimestamp() int64 {
+	if x != nil {
+		return x.ExchangeTimestamp
+	}
+	return 0
+}
+
+func (x *GetRatesResponse) GetAgeMs() int64 {
+	if x != nil {
+		return x.AgeMs
+	}
+	return 0
+}
+
+func (x *GetRatesResponse) GetIsStale() bool {
+	if x != nil {
+		return x.IsStale
+	}
+	return false
+}
+
+// Сохраненный курс
+type Rate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        int64    `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`                            // Уникальный ID записи курса
+	Ask       float64  `protobuf:"fixed64,2,opt,name=ask,proto3" json:"ask,omitempty"`                         // Первая цена ask
+	Bid       float64  `protobuf:"fixed64,3,opt,name=bid,proto3" json:"bid,omitempty"`                         // Первая цена bid
+	Timestamp int64    `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`              // Временная метка в UNIX формате
+	Market    string   `protobuf:"bytes,5,opt,name=market,proto3" json:"market,omitempty"`                     // Рынок курса
+	Source    string   `protobuf:"bytes,6,opt,name=source,proto3" json:"source,omitempty"`                     // Провайдер, вернувший курс
+	AskExact  string   `protobuf:"bytes,7,opt,name=ask_exact,json=askExact,proto3" json:"ask_exact,omitempty"` // Первая цена ask в виде точной десятичной строки
+	BidExact  string   `protobuf:"bytes,8,opt,name=bid_exact,json=bidExact,proto3" json:"bid_exact,omitempty"` // Первая цена bid в виде точной десятичной строки
+	Sources   []string `protobuf:"bytes,9,rep,name=sources,proto3" json:"sources,omitempty"`                   // Биржи, участвовавшие в расчете курса
+	Flags     []string `protobuf:"bytes,10,rep,name=flags,proto3" json:"flags,omitempty"`                      // Проверки, которые курс не прошел, если он принят с пометкой
+}
+
+func (x *Rate) Reset() {
+	*x = Rate{}
+	mi := &file_usdt_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rate) ProtoMessage() {}
+
+func (x *Rate) ProtoReflect() protoreflect.Message {
+	mi := &file_usdt_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rate.ProtoReflect.Descriptor instead.
+func (*Rate) Descriptor() ([]byte, []int) {
+	return file_usdt_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Rate) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Rate) GetAsk() float64 {
+	if x != nil {
+		return x.Ask
+	}
+	return 0
+}
+
+func (x *Rate) GetBid() float64 {
+	if x != nil {
+		return x.Bid
+	}
+	return 0
+}
+
+func (x *Rate) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *Rate) GetMarket() string {
+	if x != nil {
+		return x.Market
+	}
+	return ""
+}
+
+func (x *Rate) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Rate) GetAskExact() string {
+	if x != nil {
+		return x.AskExact
+	}
+	return ""
+}
+
+func (x *Rate) GetBidExact() string {
+	if x != nil {
+		return x.BidExact
+	}
+	return ""
+}
+
+func (x *Rate) GetSources() []string {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+func (x *Rate) GetFlags() []string {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+// Запрос для метода GetRateHistory
+type GetRateHistoryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From      int64  `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`                           // Начало периода в UNIX формате (включительно)
+	To        int64  `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`                               // Конец периода в UNIX формате (не включительно), 0 — текущий момент
+	Limit     int32  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`                         // Максимальное количество курсов на странице
+	PageToken string `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // Токен страницы из предыдущего ответа
+	Market    string `protobuf:"bytes,5,opt,name=market,proto3" json:"market,omitempty"`                        // Рынок; пустой — рынок по умолчанию
+}
+
+func (x *GetRateHistoryRequest) Reset() {
+	*x = GetRateHistoryRequest{}
+	mi := &file_usdt_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRateHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRateHistoryRequest) ProtoMessage() {}
+
+func (x *GetRateHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_usdt_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRateHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetRateHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_usdt_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetRateHistoryRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *GetRateHistoryRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *GetRateHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetRateHistoryRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *GetRateHistoryRequest) GetMarket() string {
+	if x != nil {
+		return x.Market
+	}
+	return ""
+}
+
+// Ответ для метода GetRateHistory
+type GetRateHistoryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rates         []*Rate `protobuf:"bytes,1,rep,name=rates,proto3" json:"rates,omitempty"`                                        // Курсы в порядке возрастания времени
+	NextPageToken string  `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Токен следующей страницы, пустой на последней странице
+}
+
+func (x *GetRateHistoryResponse) Reset() {
+	*x = GetRateHistoryResponse{}
+	mi := &file_usdt_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRateHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRateHistoryResponse) ProtoMessage() {}
+
+func (x *GetRateHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_usdt_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRateHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetRateHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_usdt_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetRateHistoryResponse) GetRates() []*Rate {
+	if x != nil {
+		return x.Rates
+	}
+	return nil
+}
+
+func (x *GetRateHistoryResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Запрос для метода StreamRates
+type StreamRatesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Market string `protobuf:"bytes,1,opt,name=market,proto3" json:"market,omitempty"` // Рынок; пустой — рынок по умолчанию
+}
+
+func (x *StreamRatesRequest) Reset() {
+	*x = StreamRatesRequest{}
+	mi := &file_usdt_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamRatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamRatesRequest) ProtoMessage() {}
+
+func (x *StreamRatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_usdt_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamRatesRequest.ProtoReflect.Descriptor instead.
+func (*StreamRatesRequest) Descriptor() ([]byte, []int) {
+	return file_usdt_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *StreamRatesRequest) GetMarket() string {
+	if x != nil {
+		return x.Market
+	}
+	return ""
+}
+
+// Запрос для метода GetCandles
+type GetCandlesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Interval string `protobuf:"bytes,1,opt,name=interval,proto3" json:"interval,omitempty"` // Интервал свечи: 1m, 5m, 1h или 1d
+	From     int64  `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`        // Начало периода в UNIX формате (включительно), 0 — последние 5000 свечей до to
+	To       int64  `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`            // Конец периода в UNIX формате (не включительно), 0 — текущий момент
+	Market   string `protobuf:"bytes,4,opt,name=market,proto3" json:"market,omitempty"`     // Рынок; пустой — рынок по умолчанию
+}
+
+func (x *GetCandlesRequest) Reset() {
+	*x = GetCandlesRequest{}
+	mi := &file_usdt_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCandlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCandlesRequest) ProtoMessage() {}
+
+func (x *GetCandlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_usdt_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCandlesRequest.ProtoReflect.Descriptor instead.
+func (*GetCandlesRequest) Descriptor() ([]byte, []int) {
+	return file_usdt_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetCandlesRequest) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *GetCandlesRequest) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *GetCandlesRequest) GetTo() int64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *GetCandlesRequest) GetMarket() string {
+	if x != nil {
+		return x.Market
+	}
+	return ""
+}
+
+// Цены открытия, максимума, минимума и закрытия
+type OHLC struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Open       float64 `protobuf:"fixed64,1,opt,name=open,proto3" json:"open,omitempty"`                             // Первая цена интервала
+	High       float64 `protobuf:"fixed64,2,opt,name=high,proto3" json:"high,omitempty"`                             // Максимальная цена интервала
+	Low        float64 `protobuf:"fixed64,3,opt,name=low,proto3" json:"low,omitempty"`                               // Минимальная цена интервала
+	Close      float64 `protobuf:"fixed64,4,opt,name=close,proto3" json:"close,omitempty"`                           // Последняя цена интервала
+	OpenExact  string  `protobuf:"bytes,5,opt,name=open_exact,json=openExact,proto3" json:"open_exact,omitempty"`    // Первая цена интервала в виде точной десятичной строки
+	HighExact  string  `protobuf:"bytes,6,opt,name=high_exact,json=highExact,proto3" json:"high_exact,omitempty"`    // Максимальная цена интервала в виде точной десятичной строки
+	LowExact   string  `protobuf:"bytes,7,opt,name=low_exact,json=lowExact,proto3" json:"low_exact,omitempty"`       // Минимальная цена интервала в виде точной десятичной строки
+	CloseExact string  `protobuf:"bytes,8,opt,name=close_exact,json=closeExact,proto3" json:"close_exact,omitempty"` // Последняя цена интервала в виде точной десятичной строки
+}
+
+func (x *OHLC) Reset() {
+	*x = OHLC{}
+	mi := &file_usdt_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OHLC) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OHLC) ProtoMessage() {}
+
+func (x *OHLC) ProtoReflect() protoreflect.Message {
+	mi := &file_usdt_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OHLC.ProtoReflect.Descriptor instead.
+func (*OHLC) Descriptor() ([]byte, []int) {
+	return file_usdt_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *OHLC) GetOpen() float64 {
+	if x != nil {
+		return x.Open
+	}
+	return 0
+}
+
+func (x *OHLC) GetHigh() float64 {
+	if x != nil {
+		return x.High
+	}
+	return 0
+}
+
+func (x *OHLC) GetLow() float64 {
+	if x != nil {
+		return x.Low
+	}
+	return 0
+}
+
+func (x *OHLC) GetClose() float64 {
+	if x != nil {
+		return x.Close
+	}
+	return 0
+}
+
+func (x *OHLC) GetOpenExact() string {
+	if x != nil {
+		return x.OpenExact
+	}
+	return ""
+}
+
+func (x *OHLC) GetHighExact() string {
+	if x != nil {
+		return x.HighExact
+	}
+	return ""
+}
+
+func (x *OHLC) GetLowExact() string {
+	if x != nil {
+		return x.LowExact
+	}
+	return ""
+}
+
+func (x *OHLC) GetCloseExact() string {
+	if x != nil {
+		return x.CloseExact
+	}
+	return ""
+}
+
+// Свеча курса за интервал
+type Candle struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Timestamp int64 `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Начало интервала в UNIX формате
+	Ask       *OHLC `protobuf:"bytes,2,opt,name=ask,proto3" json:"ask,omitempty"`              // Свеча по цене ask
+	Bid       *OHLC `protobuf:"bytes,3,opt,name=bid,proto3" json:"bid,omitempty"`              // Свеча по цене bid
+	Mid       *OHLC `protobuf:"bytes,4,opt,name=mid,proto3" json:"mid,omitempty"`              // Свеча по средней цене (ask+bid)/2
+	Count     int64 `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`         // Количество курсов в интервале, 0 для пустого интервала
+}
+
+func (x *Candle) Reset() {
+	*x = Candle{}
+	mi := &file_usdt_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Candle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Candle) ProtoMessage() {}
+
+func (x *Candle) ProtoReflect() protoreflect.Message {
+	mi := &file_usdt_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Candle.ProtoReflect.Descriptor instead.
+func (*Candle) Descriptor() ([]byte, []int) {
+	return file_usdt_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Candle) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *Candle) GetAsk() *OHLC {
+	if x != nil {
+		return x.Ask
+	}
+	return nil
+}
+
+func (x *Candle) GetBid() *OHLC {
+	if x != nil {
+		return x.Bid
+	}
+	return nil
+}
+
+func (x *Candle) GetMid() *OHLC {
+	if x != nil {
+		return x.Mid
+	}
+	return nil
+}
+
+func (x *Candle) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// Ответ для метода GetCandles
+type GetCandlesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Candles []*Candle `protobuf:"bytes,1,rep,name=candles,proto3" json:"candles,omitempty"` // Свечи в порядке возрастания времени
+}
+
+func (x *GetCandlesResponse) Reset() {
+	*x = GetCandlesResponse{}
+	mi := &file_usdt_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCandlesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCandlesResponse) ProtoMessage() {}
+
+func (x *GetCandlesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_usdt_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCandlesResponse.ProtoReflect.Descriptor instead.
+func (*GetCandlesResponse) Descriptor() ([]byte, []int) {
+	return file_usdt_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetCandlesResponse) GetCandles() []*Candle {
+	if x != nil {
+		return x.Candles
+	}
+	return nil
+}
+
+// Запрос для метода ListMarkets
+type ListMarketsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListMarketsRequest) Reset() {
+	*x = ListMarketsRequest{}
+	mi := &file_usdt_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMarketsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMarketsRequest) ProtoMessage() {}
+
+func (x *ListMarketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_usdt_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMarketsRequest.ProtoReflect.Descriptor instead.
+func (*ListMarketsRequest) Descriptor() ([]byte, []int) {
+	return file_usdt_proto_rawDescGZIP(), []int{10}
+}
+
+// Рынок, доступный в сервисе
+type Market struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name      string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`           // Имя рынка, например usdtrub
+	Providers []string `protobuf:"bytes,2,rep,name=providers,proto3" json:"providers,omitempty"` // Провайдеры, поддерживающие рынок
+}
+
+func (x *Market) Reset() {
+	*x = Market{}
+	mi := &file_usdt_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Market) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Market) ProtoMessage() {}
+
+func (x *Market) ProtoReflect() protoreflect.Message {
+	mi := &file_usdt_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Market.ProtoReflect.Descriptor instead.
+func (*Market) Descriptor() ([]byte, []int) {
+	return file_usdt_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Market) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Market) GetProviders() []string {
+	if x != nil {
+		return x.Providers
+	}
+	return nil
+}
+
+// Ответ для метода ListMarkets
+type ListMarketsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Markets []*Market `protobuf:"bytes,1,rep,name=markets,proto3" json:"markets,omitempty"` // Включенные рынки, первый используется по умолчанию
+}
+
+func (x *ListMarketsResponse) Reset() {
+	*x = ListMarketsResponse{}
+	mi := &file_usdt_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMarketsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMarketsResponse) ProtoMessage() {}
+
+func (x *ListMarketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_usdt_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMarketsResponse.ProtoReflect.Descriptor instead.
+func (*ListMarketsResponse) Descriptor() ([]byte, []int) {
+	return file_usdt_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListMarketsResponse) GetMarkets() []*Market {
+	if x != nil {
+		return x.Markets
+	}
+	return nil
+}
+
+// Запрос для метода GetQuote
+type GetQuoteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Market string               `protobuf:"bytes,1,opt,name=market,proto3" json:"market,omitempty"`                             // Рынок; пустой — рынок по умолчанию
+	Side   GetQuoteRequest_Side `protobuf:"varint,2,opt,name=side,proto3,enum=usdt.GetQuoteRequest_Side" json:"side,omitempty"` // Направление сделки
+	Amount string               `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`                             // Объем в базовой валюте в виде десятичной строки
+}
+
+func (x *GetQuoteRequest) Reset() {
+	*x = GetQuoteRequest{}
+	mi := &file_usdt_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuoteRequest) ProtoMessage() {}
+
+func (x *GetQuoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_usdt_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuoteRequest.ProtoReflect.Descriptor instead.
+func (*GetQuoteRequest) Descriptor() ([]byte, []int) {
+	return file_usdt_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetQuoteRequest) GetMarket() string {
+	if x != nil {
+		return x.Market
+	}
+	return ""
+}
+
+func (x *GetQuoteRequest) GetSide() GetQuoteRequest_Side {
+	if x != nil {
+		return x.Side
+	}
+	return GetQuoteRequest_UNSPECIFIED
+}
+
+func (x *GetQuoteRequest) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+// Ответ для метода GetQuote
+type GetQuoteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Market          string               `protobuf:"bytes,1,opt,name=market,proto3" json:"market,omitempty"`                                            // Рынок котировки
+	Source          string               `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`                                            // Провайдер, вернувший стакан
+	Side            GetQuoteRequest_Side `protobuf:"varint,3,opt,name=side,proto3,enum=usdt.GetQuoteRequest_Side" json:"side,omitempty"`                // Направление сделки
+	Amount          string               `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`                                            // Запрошенный объем
+	Filled          string               `protobuf:"bytes,5,opt,name=filled,proto3" json:"filled,omitempty"`                                            // Объем, который можно исполнить по стакану
+	Cost            string               `protobuf:"bytes,6,opt,name=cost,proto3" json:"cost,omitempty"`                                                // Стоимость исполненного объема в валюте котировки
+	AveragePrice    string               `protobuf:"bytes,7,opt,name=average_price,json=averagePrice,proto3" json:"average_price,omitempty"`            // Средневзвешенная цена исполнения
+	TopPrice        string               `protobuf:"bytes,8,opt,name=top_price,json=topPrice,proto3" json:"top_price,omitempty"`                        // Лучшая цена стакана
+	SlippageBps     string               `protobuf:"bytes,9,opt,name=slippage_bps,json=slippageBps,proto3" json:"slippage_bps,omitempty"`               // Проскальзывание относительно лучшей цены в базисных пунктах
+	LevelsUsed      int32                `protobuf:"varint,10,opt,name=levels_used,json=levelsUsed,proto3" json:"levels_used,omitempty"`                // Количество задействованных уровней стакана
+	EnoughLiquidity bool                 `protobuf:"varint,11,opt,name=enough_liquidity,json=enoughLiquidity,proto3" json:"enough_liquidity,omitempty"` // Хватило ли объема стакана на весь запрос
+	Timestamp       int64                `protobuf:"varint,12,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                                    // Время расчета котировки в UNIX формате
+}
+
+func (x *GetQuoteResponse) Reset() {
+	*x = GetQuoteResponse{}
+	mi := &file_usdt_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuoteResponse) ProtoMessage() {}
+
+func (x *GetQuoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_usdt_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuoteResponse.ProtoReflect.Descriptor instead.
+func (*GetQuoteResponse) Descriptor() ([]byte, []int) {
+	return file_usdt_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetQuoteResponse) GetMarket() string {
+	if x != nil {
+		return x.Market
+	}
+	return ""
+}
+
+func (x *GetQuoteResponse) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *GetQuoteResponse) GetSide() GetQuoteRequest_Side {
+	if x != nil {
+		return x.Side
+	}
+	return GetQuoteRequest_UNSPECIFIED
+}
+
+func (x *GetQuoteResponse) GetAmount() string {
+	if x != nil {
+		return x.Amount
+	}
+	return ""
+}
+
+func (x *GetQuoteResponse) GetFilled() string {
+	if x != nil {
+		return x.Filled
+	}
+	return ""
+}
+
+func (x *GetQuoteResponse) GetCost() string {
+	if x != nil {
+		return x.Cost
+	}
+	return ""
+}
+
+func (x *GetQuoteResponse) GetAveragePrice() string {
+	if x != nil {
+		return x.AveragePrice
+	}
+	return ""
+}
+
+func (x *GetQuoteResponse) GetTopPrice() string {
+	if x != nil {
+		return x.TopPrice
+	}
+	return ""
+}
+
+func (x *GetQuoteResponse) GetSlippageBps() string {
+	if x != nil {
+		return x.SlippageBps
+	}
+	return ""
+}
+
+func (x *GetQuoteResponse) GetLevelsUsed() int32 {
+	if x != nil {
+		return x.LevelsUsed
+	}
+	return 0
+}
+
+func (x *GetQuoteResponse) GetEnoughLiquidity() bool {
+	if x != nil {
+		return x.EnoughLiquidity
+	}
+	return false
+}
+
+func (x *GetQuoteResponse) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
 var File_usdt_proto protoreflect.FileDescriptor
 
 var file_usdt_proto_rawDesc = []byte{
 	0x0a, 0x0a, 0x75, 0x73, 0x64, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x75, 0x73,
-	0x64, 0x74, 0x22, 0x11, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x52, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x54, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x52, 0x61, 0x74, 0x65,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x73, 0x6b,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x61, 0x73, 0x6b, 0x12, 0x10, 0x0a, 0x03, 0x62,
-	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x62, 0x69, 0x64, 0x12, 0x1c, 0x0a,
-	0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x32, 0x49, 0x0a, 0x0c, 0x52,
-	0x61, 0x74, 0x65, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x39, 0x0a, 0x08, 0x47,
-	0x65, 0x74, 0x52, 0x61, 0x74, 0x65, 0x73, 0x12, 0x15, 0x2e, 0x75, 0x73, 0x64, 0x74, 0x2e, 0x47,
-	0x65, 0x74, 0x52, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
-	0x2e, 0x75, 0x73, 0x64, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x12, 0x5a, 0x10, 0x75, 0x73, 0x64, 0x74, 0x2f, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x64, 0x74, 0x22, 0x29, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x52, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x22, 0xcf, 0x02,
+	0x0a, 0x10, 0x47, 0x65, 0x74, 0x52, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x73, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x03, 0x61, 0x73, 0x6b, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x03, 0x62, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x73, 0x6b, 0x5f, 0x65, 0x78, 0x61, 0x63,
+	0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x61, 0x73, 0x6b, 0x45, 0x78, 0x61, 0x63,
+	0x74, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x69, 0x64, 0x5f, 0x65, 0x78, 0x61, 0x63, 0x74, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x69, 0x64, 0x45, 0x78, 0x61, 0x63, 0x74, 0x12, 0x18,
+	0x0a, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67,
+	0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x2d,
+	0x0a, 0x12, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x65, 0x78, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x15, 0x0a,
+	0x06, 0x61, 0x67, 0x65, 0x5f, 0x6d, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x61,
+	0x67, 0x65, 0x4d, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x73, 0x74, 0x61, 0x6c, 0x65,
+	0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x73, 0x53, 0x74, 0x61, 0x6c, 0x65, 0x22,
+	0xf2, 0x01, 0x0a, 0x04, 0x52, 0x61, 0x74, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x73, 0x6b, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x61, 0x73, 0x6b, 0x12, 0x10, 0x0a, 0x03, 0x62, 0x69,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x62, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61,
+	0x72, 0x6b, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x61, 0x72, 0x6b,
+	0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x73,
+	0x6b, 0x5f, 0x65, 0x78, 0x61, 0x63, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x61,
+	0x73, 0x6b, 0x45, 0x78, 0x61, 0x63, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x69, 0x64, 0x5f, 0x65,
+	0x78, 0x61, 0x63, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x69, 0x64, 0x45,
+	0x78, 0x61, 0x63, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x18,
+	0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x66,
+	0x6c, 0x61, 0x67, 0x73, 0x22, 0x88, 0x01, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x52, 0x61, 0x74, 0x65,
+	0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x66, 0x72,
+	0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02,
+	0x74, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65,
+	0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61,
+	0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x72, 0x6b, 0x65,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x22,
+	0x62, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x52, 0x61, 0x74, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72,
+	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x05, 0x72, 0x61, 0x74,
+	0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x75, 0x73, 0x64, 0x74, 0x2e,
+	0x52, 0x61, 0x74, 0x65, 0x52, 0x05, 0x72, 0x61, 0x74, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e,
+	0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x22, 0x2c, 0x0a, 0x12, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x61, 0x74,
+	0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x72,
+	0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x61, 0x72, 0x6b, 0x65,
+	0x74, 0x22, 0x6b, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x43, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76,
+	0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76,
+	0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x22, 0xd2,
+	0x01, 0x0a, 0x04, 0x4f, 0x48, 0x4c, 0x43, 0x12, 0x12, 0x0a, 0x04, 0x6f, 0x70, 0x65, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x6f, 0x70, 0x65, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x68,
+	0x69, 0x67, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x04, 0x68, 0x69, 0x67, 0x68, 0x12,
+	0x10, 0x0a, 0x03, 0x6c, 0x6f, 0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x03, 0x6c, 0x6f,
+	0x77, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x05, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6f, 0x70, 0x65, 0x6e, 0x5f,
+	0x65, 0x78, 0x61, 0x63, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x70, 0x65,
+	0x6e, 0x45, 0x78, 0x61, 0x63, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x68, 0x69, 0x67, 0x68, 0x5f, 0x65,
+	0x78, 0x61, 0x63, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x68, 0x69, 0x67, 0x68,
+	0x45, 0x78, 0x61, 0x63, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x6f, 0x77, 0x5f, 0x65, 0x78, 0x61,
+	0x63, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x6f, 0x77, 0x45, 0x78, 0x61,
+	0x63, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x5f, 0x65, 0x78, 0x61, 0x63,
+	0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x45, 0x78,
+	0x61, 0x63, 0x74, 0x22, 0x96, 0x01, 0x0a, 0x06, 0x43, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x1c,
+	0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1c, 0x0a, 0x03,
+	0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x75, 0x73, 0x64, 0x74,
+	0x2e, 0x4f, 0x48, 0x4c, 0x43, 0x52, 0x03, 0x61, 0x73, 0x6b, 0x12, 0x1c, 0x0a, 0x03, 0x62, 0x69,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x75, 0x73, 0x64, 0x74, 0x2e, 0x4f,
+	0x48, 0x4c, 0x43, 0x52, 0x03, 0x62, 0x69, 0x64, 0x12, 0x1c, 0x0a, 0x03, 0x6d, 0x69, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x75, 0x73, 0x64, 0x74, 0x2e, 0x4f, 0x48, 0x4c,
+	0x43, 0x52, 0x03, 0x6d, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x3c, 0x0a, 0x12,
+	0x47, 0x65, 0x74, 0x43, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x26, 0x0a, 0x07, 0x63, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x75, 0x73, 0x64, 0x74, 0x2e, 0x43, 0x61, 0x6e, 0x64, 0x6c,
+	0x65, 0x52, 0x07, 0x63, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x22, 0x14, 0x0a, 0x12, 0x4c, 0x69,
+	0x73, 0x74, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x3a, 0x0a, 0x06, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c,
+	0x0a, 0x09, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x73, 0x22, 0x3d, 0x0a, 0x13,
+	0x4c, 0x69, 0x73, 0x74, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x07, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x75, 0x73, 0x64, 0x74, 0x2e, 0x4d, 0x61, 0x72, 0x6b,
+	0x65, 0x74, 0x52, 0x07, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x73, 0x22, 0x9d, 0x01, 0x0a, 0x0f,
+	0x47, 0x65, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x12, 0x2e, 0x0a, 0x04, 0x73, 0x69, 0x64, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x75, 0x73, 0x64, 0x74, 0x2e, 0x47, 0x65, 0x74,
+	0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x69, 0x64,
+	0x65, 0x52, 0x04, 0x73, 0x69, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22,
+	0x2a, 0x0a, 0x04, 0x53, 0x69, 0x64, 0x65, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x42, 0x55, 0x59, 0x10,
+	0x01, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x45, 0x4c, 0x4c, 0x10, 0x02, 0x22, 0x85, 0x03, 0x0a, 0x10,
+	0x47, 0x65, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x12, 0x2e, 0x0a, 0x04, 0x73, 0x69, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a,
+	0x2e, 0x75, 0x73, 0x64, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x69, 0x64, 0x65, 0x52, 0x04, 0x73, 0x69, 0x64, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x6c,
+	0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x6c, 0x65, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x63, 0x6f, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x5f,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x76, 0x65,
+	0x72, 0x61, 0x67, 0x65, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x6f, 0x70,
+	0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x6f,
+	0x70, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x6c, 0x69, 0x70, 0x70, 0x61,
+	0x67, 0x65, 0x5f, 0x62, 0x70, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x6c,
+	0x69, 0x70, 0x70, 0x61, 0x67, 0x65, 0x42, 0x70, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x65, 0x76,
+	0x65, 0x6c, 0x73, 0x5f, 0x75, 0x73, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a,
+	0x6c, 0x65, 0x76, 0x65, 0x6c, 0x73, 0x55, 0x73, 0x65, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x65, 0x6e,
+	0x6f, 0x75, 0x67, 0x68, 0x5f, 0x6c, 0x69, 0x71, 0x75, 0x69, 0x64, 0x69, 0x74, 0x79, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x65, 0x6e, 0x6f, 0x75, 0x67, 0x68, 0x4c, 0x69, 0x71, 0x75,
+	0x69, 0x64, 0x69, 0x74, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x32, 0x99, 0x03, 0x0a, 0x0c, 0x52, 0x61, 0x74, 0x65, 0x73, 0x53, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x12, 0x39, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x52, 0x61, 0x74, 0x65, 0x73,
+	0x12, 0x15, 0x2e, 0x75, 0x73, 0x64, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x61, 0x74, 0x65, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x75, 0x73, 0x64, 0x74, 0x2e, 0x47,
+	0x65, 0x74, 0x52, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x4b, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x52, 0x61, 0x74, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72,
+	0x79, 0x12, 0x1b, 0x2e, 0x75, 0x73, 0x64, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x61, 0x74, 0x65,
+	0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c,
+	0x2e, 0x75, 0x73, 0x64, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x61, 0x74, 0x65, 0x48, 0x69, 0x73,
+	0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x0b,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x61, 0x74, 0x65, 0x73, 0x12, 0x18, 0x2e, 0x75, 0x73,
+	0x64, 0x74, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x75, 0x73, 0x64, 0x74, 0x2e, 0x47, 0x65, 0x74,
+	0x52, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12,
+	0x3f, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x43, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x12, 0x17, 0x2e,
+	0x75, 0x73, 0x64, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x75, 0x73, 0x64, 0x74, 0x2e, 0x47, 0x65,
+	0x74, 0x43, 0x61, 0x6e, 0x64, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x42, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x73, 0x12,
+	0x18, 0x2e, 0x75, 0x73, 0x64, 0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x61, 0x72, 0x6b, 0x65,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x75, 0x73, 0x64, 0x74,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4d, 0x61, 0x72, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x39, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65,
+	0x12, 0x15, 0x2e, 0x75, 0x73, 0x64, 0x74, 0x2e, 0x47, 0x65, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x75, 0x73, 0x64, 0x74, 0x2e, 0x47,
+	0x65, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42,
+	0x12, 0x5a, 0x10, 0x75, 0x73, 0x64, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x3b, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -151,19 +1354,52 @@ func file_usdt_proto_rawDescGZIP() []byte {
 	return file_usdt_proto_rawDescData
 }
 
-var file_usdt_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_usdt_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_usdt_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_usdt_proto_goTypes = []any{
-	(*GetRatesRequest)(nil),  // 0: usdt.GetRatesRequest
-	(*GetRatesResponse)(nil), // 1: usdt.GetRatesResponse
+	(GetQuoteRequest_Side)(0),      // 0: usdt.GetQuoteRequest.Side
+	(*GetRatesRequest)(nil),        // 1: usdt.GetRatesRequest
+	(*GetRatesResponse)(nil),       // 2: usdt.GetRatesResponse
+	(*Rate)(nil),                   // 3: usdt.Rate
+	(*GetRateHistoryRequest)(nil),  // 4: usdt.GetRateHistoryRequest
+	(*GetRateHistoryResponse)(nil), // 5: usdt.GetRateHistoryResponse
+	(*StreamRatesRequest)(nil),     // 6: usdt.StreamRatesRequest
+	(*GetCandlesRequest)(nil),      // 7: usdt.GetCandlesRequest
+	(*OHLC)(nil),                   // 8: usdt.OHLC
+	(*Candle)(nil),                 // 9: usdt.Candle
+	(*GetCandlesResponse)(nil),     // 10: usdt.GetCandlesResponse
+	(*ListMarketsRequest)(nil),     // 11: usdt.ListMarketsRequest
+	(*Market)(nil),                 // 12: usdt.Market
+	(*ListMarketsResponse)(nil),    // 13: usdt.ListMarketsResponse
+	(*GetQuoteRequest)(nil),        // 14: usdt.GetQuoteRequest
+	(*GetQuoteResponse)(nil),       // 15: usdt.GetQuoteResponse
 }
 var file_usdt_proto_depIdxs = []int32{
-	0, // 0: usdt.RatesService.GetRates:input_type -> usdt.GetRatesRequest
-	1, // 1: usdt.RatesService.GetRates:output_type -> usdt.GetRatesResponse
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	3,  // 0: usdt.GetRateHistoryResponse.rates:type_name -> usdt.Rate
+	8,  // 1: usdt.Candle.ask:type_name -> usdt.OHLC
+	8,  // 2: usdt.Candle.bid:type_name -> usdt.OHLC
+	8,  // 3: usdt.Candle.mid:type_name -> usdt.OHLC
+	9,  // 4: usdt.GetCandlesResponse.candles:type_name -> usdt.Candle
+	12, // 5: usdt.ListMarketsResponse.markets:type_name -> usdt.Market
+	0,  // 6: usdt.GetQuoteRequest.side:type_name -> usdt.GetQuoteRequest.Side
+	0,  // 7: usdt.GetQuoteResponse.side:type_name -> usdt.GetQuoteRequest.Side
+	1,  // 8: usdt.RatesService.GetRates:input_type -> usdt.GetRatesRequest
+	4,  // 9: usdt.RatesService.GetRateHistory:input_type -> usdt.GetRateHistoryRequest
+	6,  // 10: usdt.RatesService.StreamRates:input_type -> usdt.StreamRatesRequest
+	7,  // 11: usdt.RatesService.GetCandles:input_type -> usdt.GetCandlesRequest
+	11, // 12: usdt.RatesService.ListMarkets:input_type -> usdt.ListMarketsRequest
+	14, // 13: usdt.RatesService.GetQuote:input_type -> usdt.GetQuoteRequest
+	2,  // 14: usdt.RatesService.GetRates:output_type -> usdt.GetRatesResponse
+	5,  // 15: usdt.RatesService.GetRateHistory:output_type -> usdt.GetRateHistoryResponse
+	2,  // 16: usdt.RatesService.StreamRates:output_type -> usdt.GetRatesResponse
+	10, // 17: usdt.RatesService.GetCandles:output_type -> usdt.GetCandlesResponse
+	13, // 18: usdt.RatesService.ListMarkets:output_type -> usdt.ListMarketsResponse
+	15, // 19: usdt.RatesService.GetQuote:output_type -> usdt.GetQuoteResponse
+	14, // [14:20] is the sub-list for method output_type
+	8,  // [8:14] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_usdt_proto_init() }
@@ -176,13 +1412,14 @@ func file_usdt_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_usdt_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   2,
+			NumEnums:      1,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_usdt_proto_goTypes,
 		DependencyIndexes: file_usdt_proto_depIdxs,
+		EnumInfos:         file_usdt_proto_enumTypes,
 		MessageInfos:      file_usdt_proto_msgTypes,
 	}.Build()
 	File_usdt_proto = out.File
@@ -19,7 +19,12 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	RatesService_GetRates_FullMethodName = "/usdt.RatesService/GetRates"
+	RatesService_GetRates_FullMethodName       = "/usdt.RatesService/GetRates"
+	RatesService_GetRateHistory_FullMethodName = "/usdt.RatesService/GetRateHistory"
+	RatesService_StreamRates_FullMethodName    = "/usdt.RatesService/StreamRates"
+	RatesService_GetCandles_FullMethodName     = "/usdt.RatesService/GetCandles"
+	RatesService_ListMarkets_FullMethodName    = "/usdt.RatesService/ListMarkets"
+	RatesService_GetQuote_FullMethodName       = "/usdt.RatesService/GetQuote"
 )
 
 // RatesServiceClient is the client API for RatesService service.
@@ -30,6 +35,20 @@ const (
 type RatesServiceClient interface {
 	// Метод для получения последнего сохраненного курса USDT из хранилища
 	GetRates(ctx context.Context, in *GetRatesRequest, opts ...grpc.CallOption) (*GetRatesResponse, error)
+	// Метод для получения истории сохраненных курсов за период с постраничной выдачей
+	GetRateHistory(ctx context.Context, in *GetRateHistoryRequest, opts ...grpc.CallOption) (*GetRateHistoryResponse, error)
+	// Метод для получения потока курсов по мере их сохранения
+	StreamRates(ctx context.Context, in *StreamRatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetRatesResponse], error)
+	// Метод для получения свечей (OHLC) по сохраненным курсам.
+	// Пустые интервалы заполняются ценой предыдущей свечи, в начале периода — ценой последнего курса до from;
+	// если курсов до from нет, свечи начинаются с первого интервала с данными
+	GetCandles(ctx context.Context, in *GetCandlesRequest, opts ...grpc.CallOption) (*GetCandlesResponse, error)
+	// Метод для получения списка включенных рынков
+	ListMarkets(ctx context.Context, in *ListMarketsRequest, opts ...grpc.CallOption) (*ListMarketsResponse, error)
+	// Метод для расчета цены исполнения заданного объема по глубине стакана.
+	// Для составного провайдера используется стакан первой доступной биржи-участника;
+	// если ни одна биржа не торгует рынком, возвращается FAILED_PRECONDITION
+	GetQuote(ctx context.Context, in *GetQuoteRequest, opts ...grpc.CallOption) (*GetQuoteResponse, error)
 }
 
 type ratesServiceClient struct {
@@ -50,6 +69,65 @@ func (c *ratesServiceClient) GetRates(ctx context.Context, in *GetRatesRequest,
 	return out, nil
 }
 
+func (c *ratesServiceClient) GetRateHistory(ctx context.Context, in *GetRateHistoryRequest, opts ...grpc.CallOption) (*GetRateHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRateHistoryResponse)
+	err := c.cc.Invoke(ctx, RatesService_GetRateHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ratesServiceClient) StreamRates(ctx context.Context, in *StreamRatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetRatesResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RatesService_ServiceDesc.Streams[0], RatesService_StreamRates_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamRatesRequest, GetRatesResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RatesService_StreamRatesClient = grpc.ServerStreamingClient[GetRatesResponse]
+
+func (c *ratesServiceClient) GetCandles(ctx context.Context, in *GetCandlesRequest, opts ...grpc.CallOption) (*GetCandlesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCandlesResponse)
+	err := c.cc.Invoke(ctx, RatesService_GetCandles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ratesServiceClient) ListMarkets(ctx context.Context, in *ListMarketsRequest, opts ...grpc.CallOption) (*ListMarketsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMarketsResponse)
+	err := c.cc.Invoke(ctx, RatesService_ListMarkets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ratesServiceClient) GetQuote(ctx context.Context, in *GetQuoteRequest, opts ...grpc.CallOption) (*GetQuoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetQuoteResponse)
+	err := c.cc.Invoke(ctx, RatesService_GetQuote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RatesServiceServer is the server API for RatesService service.
 // All implementations must embed UnimplementedRatesServiceServer
 // for forward compatibility.
@@ -58,6 +136,20 @@ func (c *ratesServiceClient) GetRates(ctx context.Context, in *GetRatesRequest,
 type RatesServiceServer interface {
 	// Метод для получения последнего сохраненного курса USDT из хранилища
 	GetRates(context.Context, *GetRatesRequest) (*GetRatesResponse, error)
+	// Метод для получения истории сохраненных курсов за период с постраничной выдачей
+	GetRateHistory(context.Context, *GetRateHistoryRequest) (*GetRateHistoryResponse, error)
+	// Метод для получения потока курсов по мере их сохранения
+	StreamRates(*StreamRatesRequest, grpc.ServerStreamingServer[GetRatesResponse]) error
+	// Метод для получения свечей (OHLC) по сохраненным курсам.
+	// Пустые интервалы заполняются ценой предыдущей свечи, в начале периода — ценой последнего курса до from;
+	// если курсов до from нет, свечи начинаются с первого интервала с данными
+	GetCandles(context.Context, *GetCandlesRequest) (*GetCandlesResponse, error)
+	// Метод для получения списка включенных рынков
+	ListMarkets(context.Context, *ListMarketsRequest) (*ListMarketsResponse, error)
+	// Метод для расчета цены исполнения заданного объема по глубине стакана.
+	// Для составного провайдера используется стакан первой доступной биржи-участника;
+	// если ни одна биржа не торгует рынком, возвращается FAILED_PRECONDITION
+	GetQuote(context.Context, *GetQuoteRequest) (*GetQuoteResponse, error)
 	mustEmbedUnimplementedRatesServiceServer()
 }
 
@@ -71,6 +163,21 @@ type UnimplementedRatesServiceServer struct{}
 func (UnimplementedRatesServiceServer) GetRates(context.Context, *GetRatesRequest) (*GetRatesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRates not implemented")
 }
+func (UnimplementedRatesServiceServer) GetRateHistory(context.Context, *GetRateHistoryRequest) (*GetRateHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRateHistory not implemented")
+}
+func (UnimplementedRatesServiceServer) StreamRates(*StreamRatesRequest, grpc.ServerStreamingServer[GetRatesResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamRates not implemented")
+}
+func (UnimplementedRatesServiceServer) GetCandles(context.Context, *GetCandlesRequest) (*GetCandlesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCandles not implemented")
+}
+func (UnimplementedRatesServiceServer) ListMarkets(context.Context, *ListMarketsRequest) (*ListMarketsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMarkets not implemented")
+}
+func (UnimplementedRatesServiceServer) GetQuote(context.Context, *GetQuoteRequest) (*GetQuoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQuote not implemented")
+}
 func (UnimplementedRatesServiceServer) mustEmbedUnimplementedRatesServiceServer() {}
 func (UnimplementedRatesServiceServer) testEmbeddedByValue()                      {}
 
@@ -110,6 +217,89 @@ func _RatesService_GetRates_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RatesService_GetRateHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRateHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatesServiceServer).GetRateHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatesService_GetRateHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatesServiceServer).GetRateHistory(ctx, req.(*GetRateHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RatesService_StreamRates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamRatesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RatesServiceServer).StreamRates(m, &grpc.GenericServerStream[StreamRatesRequest, GetRatesResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RatesService_StreamRatesServer = grpc.ServerStreamingServer[GetRatesResponse]
+
+func _RatesService_GetCandles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCandlesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatesServiceServer).GetCandles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatesService_GetCandles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatesServiceServer).GetCandles(ctx, req.(*GetCandlesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RatesService_ListMarkets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMarketsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatesServiceServer).ListMarkets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatesService_ListMarkets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatesServiceServer).ListMarkets(ctx, req.(*ListMarketsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RatesService_GetQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RatesServiceServer).GetQuote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RatesService_GetQuote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RatesServiceServer).GetQuote(ctx, req.(*GetQuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RatesService_ServiceDesc is the grpc.ServiceDesc for RatesService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -121,7 +311,29 @@ var RatesService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetRates",
 			Handler:    _RatesService_GetRates_Handler,
 		},
+		{
+			MethodName: "GetRateHistory",
+			Handler:    _RatesService_GetRateHistory_Handler,
+		},
+		{
+			MethodName: "GetCandles",
+			Handler:    _RatesService_GetCandles_Handler,
+		},
+		{
+			MethodName: "ListMarkets",
+			Handler:    _RatesService_ListMarkets_Handler,
+		},
+		{
+			MethodName: "GetQuote",
+			Handler:    _RatesService_GetQuote_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamRates",
+			Handler:       _RatesService_StreamRates_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "usdt.proto",
 }
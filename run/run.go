@@ -1,15 +1,19 @@
 package run
 
 import (
+	"context"
 	"fmt"
 	"getUSDT/config"
+	"getUSDT/internal/modules/ratesService/provider"
 	"getUSDT/internal/modules/ratesService/service"
 	"getUSDT/internal/modules/ratesService/storage"
 	"getUSDT/internal/monitoring"
 	"net"
 	"net/http"
+	"time"
 
 	grpchealth "getUSDT/internal/modules/health/gRPC"
+	httphealth "getUSDT/internal/modules/health/http"
 	healthservice "getUSDT/internal/modules/health/service"
 	grpcrate "getUSDT/internal/modules/ratesService/gRPC"
 
@@ -19,12 +23,19 @@ import (
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 )
 
+// defaultExchangeMaxAge допустимая давность последнего курса с биржи, если fetch_max_age не задан
+const defaultExchangeMaxAge = time.Minute
+
 type App struct {
-	log        *zap.Logger
-	gRPCServer *grpc.Server
-	port       int
+	log           *zap.Logger
+	gRPCServer    *grpc.Server
+	ratesService  *service.RatesService
+	healthService *healthservice.HealthService
+	scheduler     *service.Scheduler
+	port          int
 }
 
 func NewApp(log *zap.Logger, cfg *config.Config, dbPostgres *sqlx.DB, tr trace.Tracer) *App {
@@ -44,19 +55,67 @@ func NewApp(log *zap.Logger, cfg *config.Config, dbPostgres *sqlx.DB, tr trace.T
 
 	// Инициализация хранилища и сервисов для RatesService
 	PostgresStorage := storage.NewRatesStorage(dbPostgres)
-	RatesService := service.NewRatesService(PostgresStorage)
+	RateProviders, err := provider.NewAll(cfg.Exchange)
+	if err != nil {
+		log.Fatal("Failed to create rate providers", zap.Error(err))
+	}
+	RateProvider, err := provider.Select(cfg.Exchange, RateProviders, metrics)
+	if err != nil {
+		log.Fatal("Failed to select rate provider", zap.Error(err))
+	}
+	RatesService := service.NewRatesService(PostgresStorage, RateProvider, cfg.Rates, metrics)
+
+	// Фоновый опрос провайдеров курсов
+	var scheduler *service.Scheduler
+	if cfg.Scheduler.Enabled {
+		scheduler = service.NewScheduler(log, RatesService, pollProviders(RateProviders, RateProvider), cfg.Scheduler)
+	}
 
 	// Регистрация RatesServer
 	grpcrate.NewRatesServer(RatesService, tr)
 	grpcrate.Register(gRPCServer, RatesService, tr)
 
 	// Регистрация HealthServer
-	HealthService := healthservice.NewHealthService()
+	HealthService := healthservice.NewHealthService(cfg.Health)
+	HealthService.AddReadinessChecker("database", healthservice.CheckerFunc(dbPostgres.PingContext))
+	if cfg.Rates.ReadyMaxAge > 0 {
+		// Сервис не готов, пока последние сохраненные курсы устарели
+		HealthService.AddReadinessChecker("rates:freshness", healthservice.CheckerFunc(func(ctx context.Context) error {
+			return RatesService.CheckFreshness(ctx, cfg.Rates.ReadyMaxAge)
+		}))
+	}
+	for _, p := range RateProviders {
+		// Состояние автоматического выключателя каждой биржи, а без него — давность последнего курса с биржи
+		if checker, ok := p.(healthservice.Checker); ok {
+			HealthService.AddChecker("exchange:"+p.Name(), checker)
+			continue
+		}
+		name := p.Name()
+		HealthService.AddChecker("exchange:"+name, healthservice.CheckerFunc(func(ctx context.Context) error {
+			return RatesService.CheckSourceFetch(ctx, name, exchangeMaxAge(cfg.Health))
+		}))
+	}
+	if cfg.Health.FetchMaxAge > 0 {
+		// Давно не было ни одного успешного запроса к бирже
+		HealthService.AddChecker("exchange:last_fetch", healthservice.CheckerFunc(func(ctx context.Context) error {
+			return RatesService.CheckLastFetch(ctx, cfg.Health.FetchMaxAge)
+		}))
+	}
+	if scheduler != nil {
+		HealthService.AddChecker("scheduler", scheduler)
+	}
 	grpchealth.NewHealthServer(HealthService, tr)
 	grpchealth.Register(gRPCServer, HealthService, tr)
+	// Стандартный grpc.health.v1 для grpc_health_probe и gRPC проб Kubernetes
+	grpchealth.RegisterStandard(gRPCServer, HealthService)
+
+	if cfg.Local.Reflection {
+		reflection.Register(gRPCServer)
+	}
 
-	// Экспозиция метрик через HTTP
+	// Экспозиция метрик и состояния приложения через HTTP
 	http.Handle("/metrics", promhttp.Handler())
+	httphealth.Register(http.DefaultServeMux, HealthService)
 	go func() {
 		if err := http.ListenAndServe(":9100", nil); err != nil {
 			log.Error("HTTP server error", zap.Error(err))
@@ -64,12 +123,45 @@ func NewApp(log *zap.Logger, cfg *config.Config, dbPostgres *sqlx.DB, tr trace.T
 	}()
 
 	return &App{
-		log:        log,
-		gRPCServer: gRPCServer,
-		port:       cfg.Local.Port,
+		log:           log,
+		gRPCServer:    gRPCServer,
+		ratesService:  RatesService,
+		healthService: HealthService,
+		scheduler:     scheduler,
+		port:          cfg.Local.Port,
 	}
 }
 
+// exchangeMaxAge возвращает допустимую давность последнего курса с биржи для проверки ее состояния
+func exchangeMaxAge(cfg config.HealthConfig) time.Duration {
+	if cfg.FetchMaxAge > 0 {
+		return cfg.FetchMaxAge
+	}
+	return defaultExchangeMaxAge
+}
+
+// pollProviders возвращает провайдеров для фонового опроса.
+// Если выбран составной провайдер или провайдер с переключением, опрашивается только он,
+// так как он сам обращается к биржам. Провайдер воспроизведения не опрашивается никогда:
+// его ответы исторические и не должны сохраняться как текущие курсы.
+func pollProviders(providers []provider.RateProvider, selected provider.RateProvider) []provider.RateProvider {
+	candidates := []provider.RateProvider{selected}
+	for _, p := range providers {
+		if p == selected {
+			candidates = providers
+			break
+		}
+	}
+
+	polled := make([]provider.RateProvider, 0, len(candidates))
+	for _, p := range candidates {
+		if p.Name() != provider.ReplayName {
+			polled = append(polled, p)
+		}
+	}
+	return polled
+}
+
 func (a *App) MustRun() {
 	if err := a.Run(); err != nil {
 		panic(err)
@@ -84,6 +176,13 @@ func (a *App) Run() error {
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	// Запускаем фоновый опрос провайдеров
+	if a.scheduler != nil {
+		a.scheduler.Start()
+	}
+	// Запускаем фоновые проверки для потоков Watch
+	a.healthService.Start()
+
 	a.log.Info("grpc server is running", zap.String("address", l.Addr().String()))
 	if err := a.gRPCServer.Serve(l); err != nil {
 		return fmt.Errorf("%s: %w", op, err)
@@ -97,5 +196,13 @@ func (a *App) Stop() {
 	a.log.With(zap.String("operation", op)).
 		Info("grpc server is stopping", zap.Int("port", a.port))
 
+	// Завершаем потоки курсов и состояния, иначе GracefulStop будет ждать их бесконечно
+	a.ratesService.Close()
+	a.healthService.Close()
 	a.gRPCServer.GracefulStop()
+
+	// Останавливаем фоновый опрос провайдеров
+	if a.scheduler != nil {
+		a.scheduler.Stop()
+	}
 }
@@ -0,0 +1,93 @@
+package run
+
+import (
+	"context"
+	"getUSDT/config"
+	"getUSDT/internal/models"
+	"getUSDT/internal/modules/ratesService/provider"
+	"getUSDT/internal/modules/ratesService/service"
+	"getUSDT/internal/modules/ratesService/service/mocks"
+	"getUSDT/internal/testutil/fakegarantex"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestPollProviders_SkipsReplay(t *testing.T) {
+	// Сохраненные ответы с заметно отличающимися ценами
+	recorded := fakegarantex.New()
+	defer recorded.Close()
+	recorded.Enqueue("usdtrub", fakegarantex.Prices("90.5", "89.5"))
+
+	dir := t.TempDir()
+	recorder, err := provider.NewGarantex(config.ProviderConfig{
+		URL:     recorded.BaseURL(),
+		Capture: config.CaptureConfig{Enabled: true, Dir: dir},
+	})
+	require.NoError(t, err)
+	_, err = recorder.FetchOrderBook(context.Background(), "usdtrub")
+	require.NoError(t, err)
+
+	exchange := fakegarantex.New()
+	defer exchange.Close()
+	exchange.Enqueue("usdtrub", fakegarantex.Prices("100.5", "99.5"))
+
+	cfg := config.ExchangeConfig{
+		Provider: provider.GarantexName,
+		Providers: []config.ProviderConfig{
+			{Name: provider.GarantexName, URL: exchange.BaseURL()},
+			{Name: provider.ReplayName, Replay: config.ReplayConfig{Dir: dir, Loop: true}},
+		},
+	}
+	providers, err := provider.NewAll(cfg)
+	require.NoError(t, err)
+	selected, err := provider.Select(cfg, providers, nil)
+	require.NoError(t, err)
+
+	polled := pollProviders(providers, selected)
+	require.Len(t, polled, 1)
+	assert.Equal(t, provider.GarantexName, polled[0].Name())
+
+	// В хранилище попадают только текущие курсы биржи
+	ctrl := gomock.NewController(t)
+	storage := mocks.NewMockRatesStorage(ctrl)
+	saved := make(chan struct{}, 10)
+	storage.EXPECT().SaveRate(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, rate *models.Rate) error {
+		assert.Equal(t, provider.GarantexName, rate.Source)
+		assert.Equal(t, "100.5", rate.Ask.String())
+		saved <- struct{}{}
+		return nil
+	}).MinTimes(2)
+
+	ratesService := service.NewRatesService(storage, selected, config.RatesConfig{}, nil)
+	scheduler := service.NewScheduler(zap.NewNop(), ratesService, polled, config.SchedulerConfig{Interval: 10 * time.Millisecond})
+	scheduler.Start()
+	defer scheduler.Stop()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-saved:
+		case <-time.After(time.Second):
+			t.Fatal("scheduler did not save rate in time")
+		}
+	}
+}
+
+func TestPollProviders_SelectedReplay(t *testing.T) {
+	dir := t.TempDir()
+	cfg := config.ExchangeConfig{
+		Provider:  provider.ReplayName,
+		Providers: []config.ProviderConfig{{Name: provider.ReplayName, Replay: config.ReplayConfig{Dir: dir}}},
+	}
+	providers, err := provider.NewAll(cfg)
+	require.NoError(t, err)
+	selected, err := provider.Select(cfg, providers, nil)
+	require.NoError(t, err)
+
+	// Выбранный провайдер воспроизведения отвечает на запросы, но в фоне не опрашивается
+	assert.Empty(t, pollProviders(providers, selected))
+}